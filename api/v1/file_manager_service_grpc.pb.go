@@ -20,11 +20,27 @@ const _ = grpc.SupportPackageIsVersion7
 type FilesServiceClient interface {
 	ListFiles(ctx context.Context, in *ListFilesRequest, opts ...grpc.CallOption) (*ListFilesResponse, error)
 	GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (*File, error)
+	UpdateFile(ctx context.Context, in *UpdateFileRequest, opts ...grpc.CallOption) (*File, error)
 	DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*DeleteFileResponse, error)
 	// CreateFileFromObjectPath creates a file from the object path in the object storage without
 	// actually uploading the file. This is mainly added to allow the worker cluster to access
 	// files without giving the access privilege to the object storage to the control plane.
 	CreateFileFromObjectPath(ctx context.Context, in *CreateFileFromObjectPathRequest, opts ...grpc.CallOption) (*File, error)
+	// CreateFileUploadURL returns a presigned URL to upload a file directly to the object storage.
+	// FinalizeFileUpload must be called after the upload to create a file.
+	CreateFileUploadURL(ctx context.Context, in *CreateFileUploadURLRequest, opts ...grpc.CallOption) (*CreateFileUploadURLResponse, error)
+	FinalizeFileUpload(ctx context.Context, in *FinalizeFileUploadRequest, opts ...grpc.CallOption) (*File, error)
+	// GetFileDownloadURL returns a presigned URL to download a file directly from the object storage.
+	GetFileDownloadURL(ctx context.Context, in *GetFileDownloadURLRequest, opts ...grpc.CallOption) (*GetFileDownloadURLResponse, error)
+	GetFileCapabilities(ctx context.Context, in *GetFileCapabilitiesRequest, opts ...grpc.CallOption) (*FileCapabilities, error)
+	// GetUsage returns the consumption of the storage quotas of the project and the tenant.
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*Usage, error)
+	// GetFileStats returns the number and the total size of files in the project broken down by
+	// purpose and status.
+	GetFileStats(ctx context.Context, in *GetFileStatsRequest, opts ...grpc.CallOption) (*FileStats, error)
+	CreateUpload(ctx context.Context, in *CreateUploadRequest, opts ...grpc.CallOption) (*Upload, error)
+	CompleteUpload(ctx context.Context, in *CompleteUploadRequest, opts ...grpc.CallOption) (*Upload, error)
+	CancelUpload(ctx context.Context, in *CancelUploadRequest, opts ...grpc.CallOption) (*Upload, error)
 }
 
 type filesServiceClient struct {
@@ -53,6 +69,15 @@ func (c *filesServiceClient) GetFile(ctx context.Context, in *GetFileRequest, op
 	return out, nil
 }
 
+func (c *filesServiceClient) UpdateFile(ctx context.Context, in *UpdateFileRequest, opts ...grpc.CallOption) (*File, error) {
+	out := new(File)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesService/UpdateFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *filesServiceClient) DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*DeleteFileResponse, error) {
 	out := new(DeleteFileResponse)
 	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesService/DeleteFile", in, out, opts...)
@@ -71,17 +96,114 @@ func (c *filesServiceClient) CreateFileFromObjectPath(ctx context.Context, in *C
 	return out, nil
 }
 
+func (c *filesServiceClient) CreateFileUploadURL(ctx context.Context, in *CreateFileUploadURLRequest, opts ...grpc.CallOption) (*CreateFileUploadURLResponse, error) {
+	out := new(CreateFileUploadURLResponse)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesService/CreateFileUploadURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filesServiceClient) FinalizeFileUpload(ctx context.Context, in *FinalizeFileUploadRequest, opts ...grpc.CallOption) (*File, error) {
+	out := new(File)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesService/FinalizeFileUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filesServiceClient) GetFileDownloadURL(ctx context.Context, in *GetFileDownloadURLRequest, opts ...grpc.CallOption) (*GetFileDownloadURLResponse, error) {
+	out := new(GetFileDownloadURLResponse)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesService/GetFileDownloadURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filesServiceClient) GetFileCapabilities(ctx context.Context, in *GetFileCapabilitiesRequest, opts ...grpc.CallOption) (*FileCapabilities, error) {
+	out := new(FileCapabilities)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesService/GetFileCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filesServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*Usage, error) {
+	out := new(Usage)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesService/GetUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filesServiceClient) GetFileStats(ctx context.Context, in *GetFileStatsRequest, opts ...grpc.CallOption) (*FileStats, error) {
+	out := new(FileStats)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesService/GetFileStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filesServiceClient) CreateUpload(ctx context.Context, in *CreateUploadRequest, opts ...grpc.CallOption) (*Upload, error) {
+	out := new(Upload)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesService/CreateUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filesServiceClient) CompleteUpload(ctx context.Context, in *CompleteUploadRequest, opts ...grpc.CallOption) (*Upload, error) {
+	out := new(Upload)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesService/CompleteUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filesServiceClient) CancelUpload(ctx context.Context, in *CancelUploadRequest, opts ...grpc.CallOption) (*Upload, error) {
+	out := new(Upload)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesService/CancelUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FilesServiceServer is the server API for FilesService service.
 // All implementations must embed UnimplementedFilesServiceServer
 // for forward compatibility
 type FilesServiceServer interface {
 	ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error)
 	GetFile(context.Context, *GetFileRequest) (*File, error)
+	UpdateFile(context.Context, *UpdateFileRequest) (*File, error)
 	DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error)
 	// CreateFileFromObjectPath creates a file from the object path in the object storage without
 	// actually uploading the file. This is mainly added to allow the worker cluster to access
 	// files without giving the access privilege to the object storage to the control plane.
 	CreateFileFromObjectPath(context.Context, *CreateFileFromObjectPathRequest) (*File, error)
+	// CreateFileUploadURL returns a presigned URL to upload a file directly to the object storage.
+	// FinalizeFileUpload must be called after the upload to create a file.
+	CreateFileUploadURL(context.Context, *CreateFileUploadURLRequest) (*CreateFileUploadURLResponse, error)
+	FinalizeFileUpload(context.Context, *FinalizeFileUploadRequest) (*File, error)
+	// GetFileDownloadURL returns a presigned URL to download a file directly from the object storage.
+	GetFileDownloadURL(context.Context, *GetFileDownloadURLRequest) (*GetFileDownloadURLResponse, error)
+	GetFileCapabilities(context.Context, *GetFileCapabilitiesRequest) (*FileCapabilities, error)
+	// GetUsage returns the consumption of the storage quotas of the project and the tenant.
+	GetUsage(context.Context, *GetUsageRequest) (*Usage, error)
+	// GetFileStats returns the number and the total size of files in the project broken down by
+	// purpose and status.
+	GetFileStats(context.Context, *GetFileStatsRequest) (*FileStats, error)
+	CreateUpload(context.Context, *CreateUploadRequest) (*Upload, error)
+	CompleteUpload(context.Context, *CompleteUploadRequest) (*Upload, error)
+	CancelUpload(context.Context, *CancelUploadRequest) (*Upload, error)
 	mustEmbedUnimplementedFilesServiceServer()
 }
 
@@ -95,12 +217,42 @@ func (UnimplementedFilesServiceServer) ListFiles(context.Context, *ListFilesRequ
 func (UnimplementedFilesServiceServer) GetFile(context.Context, *GetFileRequest) (*File, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFile not implemented")
 }
+func (UnimplementedFilesServiceServer) UpdateFile(context.Context, *UpdateFileRequest) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFile not implemented")
+}
 func (UnimplementedFilesServiceServer) DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
 }
 func (UnimplementedFilesServiceServer) CreateFileFromObjectPath(context.Context, *CreateFileFromObjectPathRequest) (*File, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateFileFromObjectPath not implemented")
 }
+func (UnimplementedFilesServiceServer) CreateFileUploadURL(context.Context, *CreateFileUploadURLRequest) (*CreateFileUploadURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFileUploadURL not implemented")
+}
+func (UnimplementedFilesServiceServer) FinalizeFileUpload(context.Context, *FinalizeFileUploadRequest) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeFileUpload not implemented")
+}
+func (UnimplementedFilesServiceServer) GetFileDownloadURL(context.Context, *GetFileDownloadURLRequest) (*GetFileDownloadURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileDownloadURL not implemented")
+}
+func (UnimplementedFilesServiceServer) GetFileCapabilities(context.Context, *GetFileCapabilitiesRequest) (*FileCapabilities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileCapabilities not implemented")
+}
+func (UnimplementedFilesServiceServer) GetUsage(context.Context, *GetUsageRequest) (*Usage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedFilesServiceServer) GetFileStats(context.Context, *GetFileStatsRequest) (*FileStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileStats not implemented")
+}
+func (UnimplementedFilesServiceServer) CreateUpload(context.Context, *CreateUploadRequest) (*Upload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUpload not implemented")
+}
+func (UnimplementedFilesServiceServer) CompleteUpload(context.Context, *CompleteUploadRequest) (*Upload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteUpload not implemented")
+}
+func (UnimplementedFilesServiceServer) CancelUpload(context.Context, *CancelUploadRequest) (*Upload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelUpload not implemented")
+}
 func (UnimplementedFilesServiceServer) mustEmbedUnimplementedFilesServiceServer() {}
 
 // UnsafeFilesServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -150,6 +302,24 @@ func _FilesService_GetFile_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FilesService_UpdateFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesServiceServer).UpdateFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesService/UpdateFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesServiceServer).UpdateFile(ctx, req.(*UpdateFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FilesService_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteFileRequest)
 	if err := dec(in); err != nil {
@@ -186,6 +356,168 @@ func _FilesService_CreateFileFromObjectPath_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FilesService_CreateFileUploadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFileUploadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesServiceServer).CreateFileUploadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesService/CreateFileUploadURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesServiceServer).CreateFileUploadURL(ctx, req.(*CreateFileUploadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilesService_FinalizeFileUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizeFileUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesServiceServer).FinalizeFileUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesService/FinalizeFileUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesServiceServer).FinalizeFileUpload(ctx, req.(*FinalizeFileUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilesService_GetFileDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileDownloadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesServiceServer).GetFileDownloadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesService/GetFileDownloadURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesServiceServer).GetFileDownloadURL(ctx, req.(*GetFileDownloadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilesService_GetFileCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesServiceServer).GetFileCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesService/GetFileCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesServiceServer).GetFileCapabilities(ctx, req.(*GetFileCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilesService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesService/GetUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilesService_GetFileStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesServiceServer).GetFileStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesService/GetFileStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesServiceServer).GetFileStats(ctx, req.(*GetFileStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilesService_CreateUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesServiceServer).CreateUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesService/CreateUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesServiceServer).CreateUpload(ctx, req.(*CreateUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilesService_CompleteUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesServiceServer).CompleteUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesService/CompleteUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesServiceServer).CompleteUpload(ctx, req.(*CompleteUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilesService_CancelUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesServiceServer).CancelUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesService/CancelUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesServiceServer).CancelUpload(ctx, req.(*CancelUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FilesService_ServiceDesc is the grpc.ServiceDesc for FilesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -201,6 +533,10 @@ var FilesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFile",
 			Handler:    _FilesService_GetFile_Handler,
 		},
+		{
+			MethodName: "UpdateFile",
+			Handler:    _FilesService_UpdateFile_Handler,
+		},
 		{
 			MethodName: "DeleteFile",
 			Handler:    _FilesService_DeleteFile_Handler,
@@ -209,6 +545,42 @@ var FilesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateFileFromObjectPath",
 			Handler:    _FilesService_CreateFileFromObjectPath_Handler,
 		},
+		{
+			MethodName: "CreateFileUploadURL",
+			Handler:    _FilesService_CreateFileUploadURL_Handler,
+		},
+		{
+			MethodName: "FinalizeFileUpload",
+			Handler:    _FilesService_FinalizeFileUpload_Handler,
+		},
+		{
+			MethodName: "GetFileDownloadURL",
+			Handler:    _FilesService_GetFileDownloadURL_Handler,
+		},
+		{
+			MethodName: "GetFileCapabilities",
+			Handler:    _FilesService_GetFileCapabilities_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _FilesService_GetUsage_Handler,
+		},
+		{
+			MethodName: "GetFileStats",
+			Handler:    _FilesService_GetFileStats_Handler,
+		},
+		{
+			MethodName: "CreateUpload",
+			Handler:    _FilesService_CreateUpload_Handler,
+		},
+		{
+			MethodName: "CompleteUpload",
+			Handler:    _FilesService_CompleteUpload_Handler,
+		},
+		{
+			MethodName: "CancelUpload",
+			Handler:    _FilesService_CancelUpload_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/file_manager_service.proto",
@@ -219,6 +591,11 @@ var FilesService_ServiceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type FilesWorkerServiceClient interface {
 	GetFilePath(ctx context.Context, in *GetFilePathRequest, opts ...grpc.CallOption) (*GetFilePathResponse, error)
+	// ListFilesPendingObjectVerification lists files whose objects could not be verified by the control plane.
+	// A worker cluster that can access the objects verifies them and reports the results
+	// with UpdateFileObjectVerification.
+	ListFilesPendingObjectVerification(ctx context.Context, in *ListFilesPendingObjectVerificationRequest, opts ...grpc.CallOption) (*ListFilesPendingObjectVerificationResponse, error)
+	UpdateFileObjectVerification(ctx context.Context, in *UpdateFileObjectVerificationRequest, opts ...grpc.CallOption) (*UpdateFileObjectVerificationResponse, error)
 }
 
 type filesWorkerServiceClient struct {
@@ -238,11 +615,34 @@ func (c *filesWorkerServiceClient) GetFilePath(ctx context.Context, in *GetFileP
 	return out, nil
 }
 
+func (c *filesWorkerServiceClient) ListFilesPendingObjectVerification(ctx context.Context, in *ListFilesPendingObjectVerificationRequest, opts ...grpc.CallOption) (*ListFilesPendingObjectVerificationResponse, error) {
+	out := new(ListFilesPendingObjectVerificationResponse)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesWorkerService/ListFilesPendingObjectVerification", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filesWorkerServiceClient) UpdateFileObjectVerification(ctx context.Context, in *UpdateFileObjectVerificationRequest, opts ...grpc.CallOption) (*UpdateFileObjectVerificationResponse, error) {
+	out := new(UpdateFileObjectVerificationResponse)
+	err := c.cc.Invoke(ctx, "/llmariner.files.server.v1.FilesWorkerService/UpdateFileObjectVerification", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FilesWorkerServiceServer is the server API for FilesWorkerService service.
 // All implementations must embed UnimplementedFilesWorkerServiceServer
 // for forward compatibility
 type FilesWorkerServiceServer interface {
 	GetFilePath(context.Context, *GetFilePathRequest) (*GetFilePathResponse, error)
+	// ListFilesPendingObjectVerification lists files whose objects could not be verified by the control plane.
+	// A worker cluster that can access the objects verifies them and reports the results
+	// with UpdateFileObjectVerification.
+	ListFilesPendingObjectVerification(context.Context, *ListFilesPendingObjectVerificationRequest) (*ListFilesPendingObjectVerificationResponse, error)
+	UpdateFileObjectVerification(context.Context, *UpdateFileObjectVerificationRequest) (*UpdateFileObjectVerificationResponse, error)
 	mustEmbedUnimplementedFilesWorkerServiceServer()
 }
 
@@ -253,6 +653,12 @@ type UnimplementedFilesWorkerServiceServer struct {
 func (UnimplementedFilesWorkerServiceServer) GetFilePath(context.Context, *GetFilePathRequest) (*GetFilePathResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFilePath not implemented")
 }
+func (UnimplementedFilesWorkerServiceServer) ListFilesPendingObjectVerification(context.Context, *ListFilesPendingObjectVerificationRequest) (*ListFilesPendingObjectVerificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFilesPendingObjectVerification not implemented")
+}
+func (UnimplementedFilesWorkerServiceServer) UpdateFileObjectVerification(context.Context, *UpdateFileObjectVerificationRequest) (*UpdateFileObjectVerificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFileObjectVerification not implemented")
+}
 func (UnimplementedFilesWorkerServiceServer) mustEmbedUnimplementedFilesWorkerServiceServer() {}
 
 // UnsafeFilesWorkerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -284,6 +690,42 @@ func _FilesWorkerService_GetFilePath_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FilesWorkerService_ListFilesPendingObjectVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFilesPendingObjectVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesWorkerServiceServer).ListFilesPendingObjectVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesWorkerService/ListFilesPendingObjectVerification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesWorkerServiceServer).ListFilesPendingObjectVerification(ctx, req.(*ListFilesPendingObjectVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilesWorkerService_UpdateFileObjectVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFileObjectVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesWorkerServiceServer).UpdateFileObjectVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.files.server.v1.FilesWorkerService/UpdateFileObjectVerification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesWorkerServiceServer).UpdateFileObjectVerification(ctx, req.(*UpdateFileObjectVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FilesWorkerService_ServiceDesc is the grpc.ServiceDesc for FilesWorkerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -295,6 +737,14 @@ var FilesWorkerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFilePath",
 			Handler:    _FilesWorkerService_GetFilePath_Handler,
 		},
+		{
+			MethodName: "ListFilesPendingObjectVerification",
+			Handler:    _FilesWorkerService_ListFilesPendingObjectVerification_Handler,
+		},
+		{
+			MethodName: "UpdateFileObjectVerification",
+			Handler:    _FilesWorkerService_UpdateFileObjectVerification_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/file_manager_service.proto",
@@ -1,4 +1,5 @@
 import * as fm from "../../fetch.pb";
+import * as GoogleProtobufField_mask from "../../google/protobuf/field_mask.pb";
 export type File = {
     id?: string;
     bytes?: string;
@@ -7,22 +8,52 @@ export type File = {
     object?: string;
     purpose?: string;
     object_store_path?: string;
+    object_verification_status?: string;
+    status?: string;
+    status_details?: string;
+    expires_at?: string;
+    sha256?: string;
+    md5?: string;
+    metadata?: {
+        [key: string]: string;
+    };
+    version?: string;
+};
+export type ExpiresAfter = {
+    anchor?: string;
+    seconds?: string;
 };
 export type ListFilesRequest = {
     purpose?: string;
     after?: string;
     limit?: number;
     order?: string;
+    status?: string;
+    filename_prefix?: string;
+    filename_contains?: string;
+    created_at_start?: string;
+    created_at_end?: string;
+    min_bytes?: string;
+    max_bytes?: string;
+    object_store_path_prefix?: string;
+    sort_by?: string;
+    metadata_selector?: string;
+    cursor?: string;
 };
 export type ListFilesResponse = {
     object?: string;
     data?: File[];
     has_more?: boolean;
     total_items?: number;
+    next_cursor?: string;
 };
 export type GetFileRequest = {
     id?: string;
 };
+export type UpdateFileRequest = {
+    file?: File;
+    update_mask?: GoogleProtobufField_mask.FieldMask;
+};
 export type DeleteFileRequest = {
     id?: string;
 };
@@ -34,6 +65,86 @@ export type DeleteFileResponse = {
 export type CreateFileFromObjectPathRequest = {
     object_path?: string;
     purpose?: string;
+    expires_after?: ExpiresAfter;
+    metadata?: {
+        [key: string]: string;
+    };
+};
+export type Upload = {
+    id?: string;
+    object?: string;
+    bytes?: string;
+    created_at?: string;
+    filename?: string;
+    purpose?: string;
+    status?: string;
+    expires_at?: string;
+    file?: File;
+};
+export type CreateUploadRequest = {
+    filename?: string;
+    purpose?: string;
+    bytes?: string;
+    mime_type?: string;
+};
+export type CompleteUploadRequest = {
+    id?: string;
+    part_ids?: string[];
+    md5?: string;
+};
+export type CancelUploadRequest = {
+    id?: string;
+};
+export type CreateFileUploadURLRequest = {
+    filename?: string;
+    purpose?: string;
+};
+export type CreateFileUploadURLResponse = {
+    file_id?: string;
+    url?: string;
+    expires_at?: string;
+};
+export type FinalizeFileUploadRequest = {
+    file_id?: string;
+    filename?: string;
+    purpose?: string;
+};
+export type GetFileDownloadURLRequest = {
+    id?: string;
+};
+export type GetFileDownloadURLResponse = {
+    url?: string;
+    expires_at?: string;
+};
+export type GetFileCapabilitiesRequest = {
+};
+export type FileCapabilities = {
+    file_upload_enabled?: boolean;
+};
+export type GetUsageRequest = {
+};
+export type QuotaUsage = {
+    bytes?: string;
+    files?: string;
+    max_bytes?: string;
+    max_files?: string;
+};
+export type Usage = {
+    project?: QuotaUsage;
+    tenant?: QuotaUsage;
+};
+export type GetFileStatsRequest = {
+};
+export type FileCount = {
+    key?: string;
+    files?: string;
+    bytes?: string;
+};
+export type FileStats = {
+    files?: string;
+    bytes?: string;
+    by_purpose?: FileCount[];
+    by_status?: FileCount[];
 };
 export type GetFilePathRequest = {
     id?: string;
@@ -41,15 +152,45 @@ export type GetFilePathRequest = {
 export type GetFilePathResponse = {
     path?: string;
     filename?: string;
+    sha256?: string;
+    md5?: string;
+    encryption_mode?: string;
+};
+export type ListFilesPendingObjectVerificationRequest = {
+    limit?: number;
+};
+export type ListFilesPendingObjectVerificationResponse = {
+    files?: File[];
+};
+export type UpdateFileObjectVerificationRequest = {
+    id?: string;
+    exists?: boolean;
+    bytes?: string;
+    etag?: string;
+    last_modified_at?: string;
+};
+export type UpdateFileObjectVerificationResponse = {
 };
 export declare class FilesService {
     static ListFiles(req: ListFilesRequest, initReq?: fm.InitReq): Promise<ListFilesResponse>;
     static GetFile(req: GetFileRequest, initReq?: fm.InitReq): Promise<File>;
+    static UpdateFile(req: UpdateFileRequest, initReq?: fm.InitReq): Promise<File>;
     static DeleteFile(req: DeleteFileRequest, initReq?: fm.InitReq): Promise<DeleteFileResponse>;
     static CreateFileFromObjectPath(req: CreateFileFromObjectPathRequest, initReq?: fm.InitReq): Promise<File>;
+    static CreateFileUploadURL(req: CreateFileUploadURLRequest, initReq?: fm.InitReq): Promise<CreateFileUploadURLResponse>;
+    static FinalizeFileUpload(req: FinalizeFileUploadRequest, initReq?: fm.InitReq): Promise<File>;
+    static GetFileDownloadURL(req: GetFileDownloadURLRequest, initReq?: fm.InitReq): Promise<GetFileDownloadURLResponse>;
+    static GetFileCapabilities(req: GetFileCapabilitiesRequest, initReq?: fm.InitReq): Promise<FileCapabilities>;
+    static GetUsage(req: GetUsageRequest, initReq?: fm.InitReq): Promise<Usage>;
+    static GetFileStats(req: GetFileStatsRequest, initReq?: fm.InitReq): Promise<FileStats>;
+    static CreateUpload(req: CreateUploadRequest, initReq?: fm.InitReq): Promise<Upload>;
+    static CompleteUpload(req: CompleteUploadRequest, initReq?: fm.InitReq): Promise<Upload>;
+    static CancelUpload(req: CancelUploadRequest, initReq?: fm.InitReq): Promise<Upload>;
 }
 export declare class FilesWorkerService {
     static GetFilePath(req: GetFilePathRequest, initReq?: fm.InitReq): Promise<GetFilePathResponse>;
+    static ListFilesPendingObjectVerification(req: ListFilesPendingObjectVerificationRequest, initReq?: fm.InitReq): Promise<ListFilesPendingObjectVerificationResponse>;
+    static UpdateFileObjectVerification(req: UpdateFileObjectVerificationRequest, initReq?: fm.InitReq): Promise<UpdateFileObjectVerificationResponse>;
 }
 export declare class FilesInternalService {
     static GetFilePath(req: GetFilePathRequest, initReq?: fm.InitReq): Promise<GetFilePathResponse>;
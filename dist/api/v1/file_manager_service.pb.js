@@ -4,6 +4,7 @@
 * This file is a generated Typescript file for GRPC Gateway, DO NOT MODIFY
 */
 import * as fm from "../../fetch.pb";
+import * as GoogleProtobufField_mask from "../../google/protobuf/field_mask.pb";
 export class FilesService {
     static ListFiles(req, initReq) {
         return fm.fetchReq(`/v1/files?${fm.renderURLSearchParams(req, [])}`, Object.assign(Object.assign({}, initReq), { method: "GET" }));
@@ -11,17 +12,53 @@ export class FilesService {
     static GetFile(req, initReq) {
         return fm.fetchReq(`/v1/files/${req["id"]}?${fm.renderURLSearchParams(req, ["id"])}`, Object.assign(Object.assign({}, initReq), { method: "GET" }));
     }
+    static UpdateFile(req, initReq) {
+        return fm.fetchReq(`/v1/files/${req["file.id"]}`, Object.assign(Object.assign({}, initReq), { method: "PATCH", body: JSON.stringify(req["file"]) }));
+    }
     static DeleteFile(req, initReq) {
         return fm.fetchReq(`/v1/files/${req["id"]}`, Object.assign(Object.assign({}, initReq), { method: "DELETE" }));
     }
     static CreateFileFromObjectPath(req, initReq) {
         return fm.fetchReq(`/v1/files:createFromObjectPath`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
     }
+    static CreateFileUploadURL(req, initReq) {
+        return fm.fetchReq(`/v1/files:createUploadURL`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
+    }
+    static FinalizeFileUpload(req, initReq) {
+        return fm.fetchReq(`/v1/files:finalizeUpload`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
+    }
+    static GetFileDownloadURL(req, initReq) {
+        return fm.fetchReq(`/v1/files/${req["id"]}/download_url?${fm.renderURLSearchParams(req, ["id"])}`, Object.assign(Object.assign({}, initReq), { method: "GET" }));
+    }
+    static GetFileCapabilities(req, initReq) {
+        return fm.fetchReq(`/v1/files:capabilities?${fm.renderURLSearchParams(req, [])}`, Object.assign(Object.assign({}, initReq), { method: "GET" }));
+    }
+    static GetUsage(req, initReq) {
+        return fm.fetchReq(`/v1/files:usage?${fm.renderURLSearchParams(req, [])}`, Object.assign(Object.assign({}, initReq), { method: "GET" }));
+    }
+    static GetFileStats(req, initReq) {
+        return fm.fetchReq(`/v1/files:stats?${fm.renderURLSearchParams(req, [])}`, Object.assign(Object.assign({}, initReq), { method: "GET" }));
+    }
+    static CreateUpload(req, initReq) {
+        return fm.fetchReq(`/v1/uploads`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
+    }
+    static CompleteUpload(req, initReq) {
+        return fm.fetchReq(`/v1/uploads/${req["id"]}/complete`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
+    }
+    static CancelUpload(req, initReq) {
+        return fm.fetchReq(`/v1/uploads/${req["id"]}/cancel`, Object.assign(Object.assign({}, initReq), { method: "POST" }));
+    }
 }
 export class FilesWorkerService {
     static GetFilePath(req, initReq) {
         return fm.fetchReq(`/llmariner.files.server.v1.FilesWorkerService/GetFilePath`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
     }
+    static ListFilesPendingObjectVerification(req, initReq) {
+        return fm.fetchReq(`/llmariner.files.server.v1.FilesWorkerService/ListFilesPendingObjectVerification`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
+    }
+    static UpdateFileObjectVerification(req, initReq) {
+        return fm.fetchReq(`/llmariner.files.server.v1.FilesWorkerService/UpdateFileObjectVerification`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
+    }
 }
 export class FilesInternalService {
     static GetFilePath(req, initReq) {
@@ -0,0 +1,3 @@
+export type FieldMask = {
+    paths?: string[];
+};
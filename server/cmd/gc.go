@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-logr/stdr"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/server"
+	"github.com/spf13/cobra"
+)
+
+func gcCmd() *cobra.Command {
+	var path string
+	var logLevel int
+	var dryRun bool
+	var gracePeriod time.Duration
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete orphaned objects under the path prefix",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := config.Parse(path)
+			if err != nil {
+				return err
+			}
+			if err := c.Validate(); err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("grace-period") {
+				gracePeriod = c.GarbageCollection.GracePeriodOrDefault()
+			}
+			if gracePeriod <= 0 {
+				return fmt.Errorf("grace period must be greater than 0")
+			}
+			stdr.SetVerbosity(logLevel)
+			if err := gc(cmd.Context(), &c, gracePeriod, dryRun); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&path, "config", "", "Path to the config file")
+	cmd.Flags().IntVar(&logLevel, "v", 0, "Log level")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report orphaned objects without deleting them")
+	cmd.Flags().DurationVar(&gracePeriod, "grace-period", 0, "Minimum age of orphaned objects to be deleted. Defaults to the value in the config file")
+	_ = cmd.MarkFlagRequired("config")
+	return cmd
+}
+
+func gc(ctx context.Context, c *config.Config, gracePeriod time.Duration, dryRun bool) error {
+	if c.ObjectStore == nil || (c.Debug.Standalone && c.ObjectStore.Filesystem == nil) {
+		return fmt.Errorf("objectStore must be configured")
+	}
+
+	logger := stdr.New(log.Default())
+
+	st, err := newStore(c)
+	if err != nil {
+		return err
+	}
+
+	objectStore, pathPrefix, err := newObjectStoreClient(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	g := server.NewGarbageCollector(st, objectStore, c.ObjectStore.Bucket(), pathPrefix, gracePeriod, dryRun, logger)
+	keys, err := g.Collect(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if dryRun {
+			fmt.Printf("Would delete %s\n", key)
+		} else {
+			fmt.Printf("Deleted %s\n", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+func quotaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quota",
+		Short: "Manage the quota overrides of projects and tenants",
+	}
+	cmd.AddCommand(quotaGetCmd())
+	cmd.AddCommand(quotaSetCmd())
+	cmd.AddCommand(quotaDeleteCmd())
+	return cmd
+}
+
+// quotaFlags is the flags common to the quota subcommands.
+type quotaFlags struct {
+	path    string
+	scope   string
+	scopeID string
+}
+
+func (f *quotaFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.path, "config", "", "Path to the config file")
+	cmd.Flags().StringVar(&f.scope, "scope", "", "Scope of the quota. One of \"project\" and \"tenant\"")
+	cmd.Flags().StringVar(&f.scopeID, "id", "", "ID of the project or the tenant")
+	_ = cmd.MarkFlagRequired("config")
+	_ = cmd.MarkFlagRequired("scope")
+	_ = cmd.MarkFlagRequired("id")
+}
+
+// open validates the flags and opens the store.
+func (f *quotaFlags) open() (*store.S, store.QuotaScope, error) {
+	scope := store.QuotaScope(f.scope)
+	switch scope {
+	case store.QuotaScopeProject, store.QuotaScopeTenant:
+	default:
+		return nil, "", fmt.Errorf("invalid scope %q. must be %q or %q", f.scope, store.QuotaScopeProject, store.QuotaScopeTenant)
+	}
+	if f.scopeID == "" {
+		return nil, "", fmt.Errorf("id must be set")
+	}
+
+	c, err := config.Parse(f.path)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, "", err
+	}
+	st, err := newStore(&c)
+	if err != nil {
+		return nil, "", err
+	}
+	return st, scope, nil
+}
+
+func quotaGetCmd() *cobra.Command {
+	var f quotaFlags
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show the quota override and the usage of a project or a tenant",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			st, scope, err := f.open()
+			if err != nil {
+				return err
+			}
+			u, err := st.GetFileUsage(scope, f.scopeID)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Usage: %d files, %d bytes\n", u.Files, u.Bytes)
+
+			o, err := st.GetQuotaOverride(scope, f.scopeID)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					fmt.Println("No override. The defaults in the config file are used.")
+					return nil
+				}
+				return err
+			}
+			fmt.Printf("Max files: %s\n", formatQuotaLimit(o.MaxFiles))
+			fmt.Printf("Max bytes: %s\n", formatQuotaLimit(o.MaxBytes))
+			return nil
+		},
+	}
+	f.register(cmd)
+	return cmd
+}
+
+func quotaSetCmd() *cobra.Command {
+	var f quotaFlags
+	var maxBytes, maxFiles int64
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Override the quota of a project or a tenant",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o := &store.QuotaOverride{
+				ScopeID: f.scopeID,
+			}
+			// Limits without flags fall back to the defaults.
+			if cmd.Flags().Changed("max-bytes") {
+				if maxBytes < 0 {
+					return fmt.Errorf("max-bytes must be non-negative")
+				}
+				o.MaxBytes = &maxBytes
+			}
+			if cmd.Flags().Changed("max-files") {
+				if maxFiles < 0 {
+					return fmt.Errorf("max-files must be non-negative")
+				}
+				o.MaxFiles = &maxFiles
+			}
+			if o.MaxBytes == nil && o.MaxFiles == nil {
+				return fmt.Errorf("max-bytes or max-files must be set")
+			}
+
+			st, scope, err := f.open()
+			if err != nil {
+				return err
+			}
+			o.Scope = scope
+			if err := st.UpsertQuotaOverride(o); err != nil {
+				return err
+			}
+			fmt.Printf("Overrode the quota of %s %q\n", scope, f.scopeID)
+			return nil
+		},
+	}
+	f.register(cmd)
+	cmd.Flags().Int64Var(&maxBytes, "max-bytes", 0, "Maximum total size of files. 0 removes the limit. The default is used if not set")
+	cmd.Flags().Int64Var(&maxFiles, "max-files", 0, "Maximum number of files. 0 removes the limit. The default is used if not set")
+	return cmd
+}
+
+func quotaDeleteCmd() *cobra.Command {
+	var f quotaFlags
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete the quota override of a project or a tenant so that the defaults are used",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			st, scope, err := f.open()
+			if err != nil {
+				return err
+			}
+			if err := st.DeleteQuotaOverride(scope, f.scopeID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return fmt.Errorf("no override for %s %q", scope, f.scopeID)
+				}
+				return err
+			}
+			fmt.Printf("Deleted the quota override of %s %q\n", scope, f.scopeID)
+			return nil
+		},
+	}
+	f.register(cmd)
+	return cmd
+}
+
+func formatQuotaLimit(v *int64) string {
+	switch {
+	case v == nil:
+		return "default"
+	case *v == 0:
+		return "unlimited"
+	default:
+		return fmt.Sprint(*v)
+	}
+}
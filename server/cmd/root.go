@@ -10,5 +10,8 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(runCmd())
+	rootCmd.AddCommand(gcCmd())
+	rootCmd.AddCommand(scrubCmd())
+	rootCmd.AddCommand(quotaCmd())
 	rootCmd.SilenceUsage = true
 }
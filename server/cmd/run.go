@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/go-logr/stdr"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -13,6 +14,7 @@ import (
 	"github.com/llmariner/common/pkg/db"
 	v1 "github.com/llmariner/file-manager/api/v1"
 	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/filesystem"
 	"github.com/llmariner/file-manager/server/internal/s3"
 	"github.com/llmariner/file-manager/server/internal/server"
 	"github.com/llmariner/file-manager/server/internal/store"
@@ -26,6 +28,12 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	objectDeletionInterval   = 10 * time.Second
+	uploadExpirationInterval = time.Minute
+	fileExpirationInterval   = time.Minute
+)
+
 func runCmd() *cobra.Command {
 	var path string
 	var logLevel int
@@ -57,22 +65,11 @@ func run(ctx context.Context, c *config.Config) error {
 	logger := stdr.New(log.Default())
 	log := logger.WithName("boot")
 
-	var dbInst *gorm.DB
-	var err error
-	if c.Debug.Standalone {
-		dbInst, err = gorm.Open(sqlite.Open(c.Debug.SqlitePath), &gorm.Config{})
-	} else {
-		dbInst, err = db.OpenDB(c.Database)
-	}
+	st, err := newStore(c)
 	if err != nil {
 		return err
 	}
 
-	st := store.New(dbInst)
-	if err := st.AutoMigrate(); err != nil {
-		return err
-	}
-
 	addr := fmt.Sprintf("localhost:%d", c.GRPCPort)
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 	conn, err := grpc.NewClient(addr, opts...)
@@ -109,19 +106,11 @@ func run(ctx context.Context, c *config.Config) error {
 		usageSetter = sender.NoopUsageSetter{}
 	}
 
-	var s3Client server.S3Client
-	var pathPrefix string
-	if c.Debug.Standalone || c.ObjectStore == nil {
-		s3Client = &server.NoopS3Client{}
-	} else {
-		s3conf := c.ObjectStore.S3
-		s3Client, err = s3.NewClient(ctx, s3conf)
-		if err != nil {
-			return err
-		}
-		pathPrefix = s3conf.PathPrefix
+	objectStore, pathPrefix, err := newObjectStoreClient(ctx, c)
+	if err != nil {
+		return err
 	}
-	s := server.New(st, s3Client, usageSetter, pathPrefix, c.EnableFileUpload, logger)
+	s := server.New(st, objectStore, usageSetter, pathPrefix, c.EnableFileUpload, c.Quota, c.EnableDeduplication, logger)
 	createFile := runtime.MustPattern(runtime.NewPattern(
 		1,
 		[]int{2, 0, 2, 1},
@@ -135,7 +124,14 @@ func run(ctx context.Context, c *config.Config) error {
 		[]string{"v1", "files", "id", "content"},
 		"",
 	))
-	mux.Handle("Get", getFileContent, s.GetFileContent)
+	mux.Handle("GET", getFileContent, s.GetFileContent)
+	addUploadPart := runtime.MustPattern(runtime.NewPattern(
+		1,
+		[]int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2, 2, 3},
+		[]string{"v1", "uploads", "id", "parts"},
+		"",
+	))
+	mux.Handle("POST", addUploadPart, s.AddUploadPart)
 
 	errCh := make(chan error)
 	go func() {
@@ -152,6 +148,28 @@ func run(ctx context.Context, c *config.Config) error {
 		errCh <- s.Run(ctx, c.WorkerServiceGRPCPort, c.AuthConfig)
 	}()
 
+	go func() {
+		d := server.NewObjectDeleter(st, objectStore, logger)
+		errCh <- d.Run(ctx, objectDeletionInterval)
+	}()
+
+	go func() {
+		e := server.NewUploadExpirer(st, objectStore, logger)
+		errCh <- e.Run(ctx, uploadExpirationInterval)
+	}()
+
+	go func() {
+		r := server.NewFileReaper(st, logger)
+		errCh <- r.Run(ctx, fileExpirationInterval)
+	}()
+
+	if gc := c.GarbageCollection; gc.Enabled(c.EnableFileUpload) && pathPrefix != "" {
+		go func() {
+			g := server.NewGarbageCollector(st, objectStore, c.ObjectStore.Bucket(), pathPrefix, gc.GracePeriodOrDefault(), gc.DryRunOrDefault(), logger)
+			errCh <- g.Run(ctx, gc.IntervalOrDefault())
+		}()
+	}
+
 	go func() {
 		s := server.NewInternal(st, logger)
 		errCh <- s.Run(c.InternalGRPCPort)
@@ -159,3 +177,45 @@ func run(ctx context.Context, c *config.Config) error {
 
 	return <-errCh
 }
+
+// newObjectStoreClient returns a client of the configured object store and the path prefix of uploaded objects.
+// The filesystem object store is used if configured. Otherwise, S3 is used unless the server runs in
+// the standalone mode or no object store is configured. The client is created even when file upload is
+// disabled so that the objects of files created from object paths are verified.
+func newObjectStoreClient(ctx context.Context, c *config.Config) (server.ObjectStore, string, error) {
+	if oc := c.ObjectStore; oc != nil && oc.Filesystem != nil {
+		client, err := filesystem.NewClient(*oc.Filesystem)
+		if err != nil {
+			return nil, "", err
+		}
+		return client, oc.Filesystem.PathPrefix, nil
+	}
+	if c.Debug.Standalone || c.ObjectStore == nil {
+		return &server.NoopObjectStore{}, "", nil
+	}
+	s3conf := c.ObjectStore.S3
+	client, err := s3.NewClient(ctx, s3conf)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, s3conf.PathPrefix, nil
+}
+
+func newStore(c *config.Config) (*store.S, error) {
+	var dbInst *gorm.DB
+	var err error
+	if c.Debug.Standalone {
+		dbInst, err = gorm.Open(sqlite.Open(c.Debug.SqlitePath), &gorm.Config{})
+	} else {
+		dbInst, err = db.OpenDB(c.Database)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	st := store.New(dbInst)
+	if err := st.AutoMigrate(); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
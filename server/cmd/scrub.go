@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/go-logr/stdr"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/server"
+	"github.com/spf13/cobra"
+)
+
+func scrubCmd() *cobra.Command {
+	var path string
+	var logLevel int
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "scrub",
+		Short: "Re-read uploaded objects and verify their checksums",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := config.Parse(path)
+			if err != nil {
+				return err
+			}
+			if err := c.Validate(); err != nil {
+				return err
+			}
+			stdr.SetVerbosity(logLevel)
+			if err := scrub(cmd.Context(), &c, dryRun); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&path, "config", "", "Path to the config file")
+	cmd.Flags().IntVar(&logLevel, "v", 0, "Log level")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report mismatches without updating files")
+	_ = cmd.MarkFlagRequired("config")
+	return cmd
+}
+
+func scrub(ctx context.Context, c *config.Config, dryRun bool) error {
+	if c.ObjectStore == nil || (c.Debug.Standalone && c.ObjectStore.Filesystem == nil) {
+		return fmt.Errorf("objectStore must be configured")
+	}
+
+	logger := stdr.New(log.Default())
+
+	st, err := newStore(c)
+	if err != nil {
+		return err
+	}
+
+	objectStore, _, err := newObjectStoreClient(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	s := server.NewScrubber(st, objectStore, dryRun, logger)
+	ms, err := s.Scrub(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range ms {
+		fmt.Printf("%s (%s): %s\n", m.FileID, m.ObjectStorePath, m.Reason)
+	}
+	if len(ms) > 0 {
+		return fmt.Errorf("found %d mismatches", len(ms))
+	}
+	return nil
+}
@@ -3,12 +3,18 @@ package config
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"time"
 
 	"github.com/llmariner/api-usage/pkg/sender"
 	"github.com/llmariner/common/pkg/db"
+	"github.com/llmariner/file-manager/server/internal/objectstore"
 	"gopkg.in/yaml.v3"
 )
 
+// maxPresignedURLExpiry is the maximum expiry of presigned URLs allowed by S3.
+const maxPresignedURLExpiry = 7 * 24 * time.Hour
+
 // AssumeRoleConfig is the assume role configuration.
 type AssumeRoleConfig struct {
 	RoleARN    string `yaml:"roleArn"`
@@ -30,16 +36,133 @@ type S3Config struct {
 	Bucket             string `yaml:"bucket"`
 	PathPrefix         string `yaml:"pathPrefix"`
 
+	// PresignedURLExpiry is the expiry of presigned upload and download URLs. Defaults to 15 minutes.
+	PresignedURLExpiry time.Duration `yaml:"presignedUrlExpiry"`
+
 	AssumeRole *AssumeRoleConfig `yaml:"assumeRole"`
+
+	// Upload is the configuration of the uploads of files streamed to S3.
+	Upload S3UploadConfig `yaml:"upload"`
+
+	// Encryption is the server-side encryption of uploaded objects.
+	Encryption EncryptionConfig `yaml:"encryption"`
+	// TenantEncryption overrides Encryption for the tenants keyed by their IDs.
+	TenantEncryption map[string]EncryptionConfig `yaml:"tenantEncryption"`
+}
+
+// S3UploadConfig is the configuration of the uploads of files streamed to S3.
+//
+// A streamed file is uploaded in parts, each of which is buffered in memory. An upload holds up to
+// (Concurrency + 1) * PartSizeMiB MiB of memory, which is 48 MiB with the defaults. The maximum size of
+// a file is 10,000 * PartSizeMiB MiB (about 156 GiB with the defaults) as S3 allows up to 10,000 parts.
+type S3UploadConfig struct {
+	// PartSizeMiB is the size of a part in MiB. It must be at least 5. Defaults to 16.
+	PartSizeMiB int64 `yaml:"partSizeMiB"`
+	// Concurrency is the number of parts uploaded concurrently. Defaults to 2.
+	Concurrency int `yaml:"concurrency"`
+}
+
+func (c *S3UploadConfig) validate() error {
+	if c.PartSizeMiB != 0 && c.PartSizeMiB < 5 {
+		return fmt.Errorf("partSizeMiB must be at least 5")
+	}
+	if c.Concurrency < 0 {
+		return fmt.Errorf("concurrency must be non-negative")
+	}
+	return nil
+}
+
+// EncryptionConfig is the server-side encryption configuration of uploaded objects.
+type EncryptionConfig struct {
+	// Mode is one of "sse-s3", "sse-kms", and "sse-c". Objects are not encrypted by the server if empty,
+	// but the default encryption of the bucket still applies.
+	Mode string `yaml:"mode"`
+	// KMSKeyID is the ID of the KMS key used with "sse-kms". The AWS managed key is used if empty.
+	KMSKeyID string `yaml:"kmsKeyId"`
+	// CustomerKeyEnvName is the name of the environment variable that contains the base64-encoded
+	// 256-bit key used with "sse-c".
+	CustomerKeyEnvName string `yaml:"customerKeyEnvName"`
+}
+
+// CustomerKey returns the base64-encoded key used with "sse-c".
+func (c *EncryptionConfig) CustomerKey() string {
+	return os.Getenv(c.CustomerKeyEnvName)
+}
+
+func (c *EncryptionConfig) validate() error {
+	switch c.Mode {
+	case "", objectstore.EncryptionModeSSES3:
+	case objectstore.EncryptionModeSSEKMS:
+	case objectstore.EncryptionModeSSEC:
+		if c.CustomerKeyEnvName == "" {
+			return fmt.Errorf("customerKeyEnvName must be set")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown mode %q", c.Mode)
+	}
+	if c.CustomerKeyEnvName != "" {
+		return fmt.Errorf("customerKeyEnvName must not be set with mode %q", c.Mode)
+	}
+	if c.KMSKeyID != "" && c.Mode != objectstore.EncryptionModeSSEKMS {
+		return fmt.Errorf("kmsKeyId must not be set with mode %q", c.Mode)
+	}
+	return nil
 }
 
-// ObjectStoreConfig is the object store configuration.
+// FilesystemConfig is the configuration of the object store backed by a local filesystem.
+type FilesystemConfig struct {
+	// RootDir is the directory where objects are stored.
+	RootDir    string `yaml:"rootDir"`
+	PathPrefix string `yaml:"pathPrefix"`
+}
+
+func (c *FilesystemConfig) validate() error {
+	if c.RootDir == "" {
+		return fmt.Errorf("rootDir must be set")
+	}
+	if c.PathPrefix == "" {
+		return fmt.Errorf("pathPrefix must be set")
+	}
+	return nil
+}
+
+// ObjectStoreConfig is the object store configuration. Either S3 or Filesystem must be set.
 type ObjectStoreConfig struct {
 	S3 S3Config `yaml:"s3"`
+	// Filesystem stores objects in a local filesystem instead of S3. This is intended for
+	// standalone and single-node installations.
+	Filesystem *FilesystemConfig `yaml:"filesystem"`
+}
+
+// PathPrefix returns the prefix of the keys of objects uploaded to the object store.
+func (c *ObjectStoreConfig) PathPrefix() string {
+	if c.Filesystem != nil {
+		return c.Filesystem.PathPrefix
+	}
+	return c.S3.PathPrefix
+}
+
+// Bucket returns the bucket of the S3 object store. It returns an empty string if the filesystem object store is used.
+func (c *ObjectStoreConfig) Bucket() string {
+	if c.Filesystem != nil {
+		return ""
+	}
+	return c.S3.Bucket
 }
 
 // Validate validates the object store configuration.
 func (c *ObjectStoreConfig) Validate() error {
+	if fc := c.Filesystem; fc != nil {
+		if !reflect.DeepEqual(c.S3, S3Config{}) {
+			return fmt.Errorf("s3 and filesystem must not be set at the same time")
+		}
+		if err := fc.validate(); err != nil {
+			return fmt.Errorf("filesystem: %s", err)
+		}
+		return nil
+	}
+
 	if c.S3.Region == "" {
 		return fmt.Errorf("s3 region must be set")
 	}
@@ -49,11 +172,123 @@ func (c *ObjectStoreConfig) Validate() error {
 	if c.S3.PathPrefix == "" {
 		return fmt.Errorf("s3 path prefix must be set")
 	}
+	if e := c.S3.PresignedURLExpiry; e < 0 || e > maxPresignedURLExpiry {
+		return fmt.Errorf("s3 presignedUrlExpiry must be between 0 and %s", maxPresignedURLExpiry)
+	}
 	if ar := c.S3.AssumeRole; ar != nil {
 		if err := ar.validate(); err != nil {
 			return fmt.Errorf("assumeRole: %s", err)
 		}
 	}
+	if err := c.S3.Upload.validate(); err != nil {
+		return fmt.Errorf("s3 upload: %s", err)
+	}
+	if err := c.S3.Encryption.validate(); err != nil {
+		return fmt.Errorf("s3 encryption: %s", err)
+	}
+	for tenantID, e := range c.S3.TenantEncryption {
+		if err := e.validate(); err != nil {
+			return fmt.Errorf("s3 tenantEncryption %q: %s", tenantID, err)
+		}
+	}
+	return nil
+}
+
+const (
+	defaultGarbageCollectionInterval    = time.Hour
+	defaultGarbageCollectionGracePeriod = 24 * time.Hour
+)
+
+// GarbageCollectionConfig is the configuration of the garbage collection of orphaned objects.
+type GarbageCollectionConfig struct {
+	// Enable enables the garbage collection in the server. If not set, the garbage collection runs in
+	// the dry-run mode when file upload is enabled so that orphaned objects, such as the objects uploaded
+	// with presigned URLs whose uploads are not finalized, are reported.
+	Enable *bool `yaml:"enable"`
+	// Interval is the interval between garbage collection runs. Defaults to 1 hour.
+	Interval time.Duration `yaml:"interval"`
+	// GracePeriod is the minimum age of an orphaned object to be deleted. This prevents
+	// the deletion of objects whose files are being created, including the objects of the parts
+	// of pending uploads, which expire in 1 hour. Defaults to 24 hours.
+	GracePeriod time.Duration `yaml:"gracePeriod"`
+	// DryRun makes the garbage collector only report orphaned objects without deleting them.
+	DryRun bool `yaml:"dryRun"`
+}
+
+// Enabled returns true if the garbage collection runs in the server.
+func (c *GarbageCollectionConfig) Enabled(enableFileUpload bool) bool {
+	if c.Enable != nil {
+		return *c.Enable
+	}
+	return enableFileUpload
+}
+
+// DryRunOrDefault returns true if the garbage collection only reports orphaned objects without deleting them.
+// Objects are deleted only if the garbage collection is explicitly enabled.
+func (c *GarbageCollectionConfig) DryRunOrDefault() bool {
+	return c.DryRun || c.Enable == nil
+}
+
+// IntervalOrDefault returns the interval between garbage collection runs.
+func (c *GarbageCollectionConfig) IntervalOrDefault() time.Duration {
+	if c.Interval == 0 {
+		return defaultGarbageCollectionInterval
+	}
+	return c.Interval
+}
+
+// GracePeriodOrDefault returns the minimum age of an orphaned object to be deleted.
+func (c *GarbageCollectionConfig) GracePeriodOrDefault() time.Duration {
+	if c.GracePeriod == 0 {
+		return defaultGarbageCollectionGracePeriod
+	}
+	return c.GracePeriod
+}
+
+// Validate validates the configuration.
+func (c *GarbageCollectionConfig) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("interval must not be negative")
+	}
+	if c.GracePeriod < 0 {
+		return fmt.Errorf("gracePeriod must not be negative")
+	}
+	return nil
+}
+
+// QuotaLimits is the limits of files. There is no limit if a value is 0.
+type QuotaLimits struct {
+	// MaxBytes is the maximum total size of files.
+	MaxBytes int64 `yaml:"maxBytes"`
+	// MaxFiles is the maximum number of files.
+	MaxFiles int64 `yaml:"maxFiles"`
+}
+
+func (l *QuotaLimits) validate() error {
+	if l.MaxBytes < 0 {
+		return fmt.Errorf("maxBytes must be non-negative")
+	}
+	if l.MaxFiles < 0 {
+		return fmt.Errorf("maxFiles must be non-negative")
+	}
+	return nil
+}
+
+// QuotaConfig is the default quotas of projects and tenants. The quotas can be overridden per project or tenant
+// in the database.
+type QuotaConfig struct {
+	Project QuotaLimits `yaml:"project"`
+	Tenant  QuotaLimits `yaml:"tenant"`
+}
+
+// Validate validates the configuration.
+func (c *QuotaConfig) Validate() error {
+	if err := c.Project.validate(); err != nil {
+		return fmt.Errorf("project: %s", err)
+	}
+	if err := c.Tenant.validate(); err != nil {
+		return fmt.Errorf("tenant: %s", err)
+	}
 	return nil
 }
 
@@ -87,10 +322,17 @@ type Config struct {
 	WorkerServiceGRPCPort int  `yaml:"workerServiceGrpcPort"`
 	InternalGRPCPort      int  `yaml:"internalGrpcPort"`
 	EnableFileUpload      bool `yaml:"enableFileUpload"`
+	// EnableDeduplication makes files uploaded with CreateFile share an object with other files
+	// having the same content in the tenant.
+	EnableDeduplication bool `yaml:"enableDeduplication"`
 
 	Database    db.Config          `yaml:"database"`
 	ObjectStore *ObjectStoreConfig `yaml:"objectStore"`
 
+	GarbageCollection GarbageCollectionConfig `yaml:"garbageCollection"`
+
+	Quota QuotaConfig `yaml:"quota"`
+
 	AuthConfig  AuthConfig    `yaml:"auth"`
 	UsageSender sender.Config `yaml:"usageSender"`
 
@@ -116,16 +358,22 @@ func (c *Config) Validate() error {
 		if c.Debug.SqlitePath == "" {
 			return fmt.Errorf("sqlite path must be set")
 		}
-	} else {
-		if c.EnableFileUpload {
-			if c.ObjectStore == nil {
-				return fmt.Errorf("objectStore must be set when file upload is enabled")
+		// Only the filesystem object store is used in the standalone mode. Uploaded files are discarded if not set.
+		if c.ObjectStore != nil && c.ObjectStore.Filesystem != nil {
+			if err := c.ObjectStore.Validate(); err != nil {
+				return fmt.Errorf("object store: %s", err)
 			}
+		}
+	} else {
+		if c.EnableFileUpload && c.ObjectStore == nil {
+			return fmt.Errorf("objectStore must be set when file upload is enabled")
+		}
+		// The object store can be set when file upload is disabled so that the objects of files created
+		// from object paths are verified and read. Read-only credentials are sufficient in that case.
+		if c.ObjectStore != nil {
 			if err := c.ObjectStore.Validate(); err != nil {
 				return fmt.Errorf("object store: %s", err)
 			}
-		} else if c.ObjectStore != nil {
-			return fmt.Errorf("objectStore must not be set when file upload is disabled")
 		}
 
 		if err := c.Database.Validate(); err != nil {
@@ -133,6 +381,12 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if err := c.GarbageCollection.Validate(); err != nil {
+		return fmt.Errorf("garbageCollection: %s", err)
+	}
+	if err := c.Quota.Validate(); err != nil {
+		return fmt.Errorf("quota: %s", err)
+	}
 	if err := c.AuthConfig.Validate(); err != nil {
 		return err
 	}
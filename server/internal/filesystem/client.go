@@ -0,0 +1,340 @@
+package filesystem
+
+import (
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/llmariner/common/pkg/id"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/objectstore"
+)
+
+// tmpDir is the directory under the root directory where temporary files and parts of multipart uploads
+// are stored. Objects are written to temporary files first and then renamed so that readers never see
+// partially written objects.
+const tmpDir = ".tmp"
+
+// NewClient returns a new client that stores objects under the root directory.
+func NewClient(c config.FilesystemConfig) (*Client, error) {
+	root, err := filepath.Abs(c.RootDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Join(root, tmpDir), 0o750); err != nil {
+		return nil, err
+	}
+	return &Client{
+		root: root,
+	}, nil
+}
+
+// Client is a client of the object store backed by a local filesystem. An object is stored
+// as a file whose path relative to the root directory is its key.
+type Client struct {
+	root string
+}
+
+// EncryptionMode returns the server-side encryption mode applied to new objects of the tenant.
+// Objects are not encrypted.
+func (c *Client) EncryptionMode(tenantID string) string {
+	return ""
+}
+
+// Upload uploads the content to the object and returns its ETag.
+func (c *Client) Upload(ctx context.Context, r io.Reader, key string, enc objectstore.Encryption) (string, error) {
+	if err := checkEncryption(enc); err != nil {
+		return "", err
+	}
+	path, err := c.path(key)
+	if err != nil {
+		return "", err
+	}
+	return c.writeFile(path, r)
+}
+
+// Download returns the content of the object in the given byte range.
+func (c *Client) Download(ctx context.Context, key string, offset, length int64, enc objectstore.Encryption) (io.ReadCloser, error) {
+	if err := checkEncryption(enc); err != nil {
+		return nil, err
+	}
+	path, err := c.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &readCloser{
+		Reader: io.LimitReader(f, length),
+		Closer: f,
+	}, nil
+}
+
+// DownloadBucketObject is not supported as objects in other buckets are not accessible.
+func (c *Client) DownloadBucketObject(ctx context.Context, bucket, key string, offset, length int64) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("download bucket object: %w", errors.ErrUnsupported)
+}
+
+// Copy copies the object to another key.
+func (c *Client) Copy(ctx context.Context, srcKey, dstKey string, enc objectstore.Encryption) error {
+	if err := checkEncryption(enc); err != nil {
+		return err
+	}
+	src, err := c.path(srcKey)
+	if err != nil {
+		return err
+	}
+	dst, err := c.path(dstKey)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = c.writeFile(dst, f)
+	return err
+}
+
+// Delete deletes the object. It does not return an error if the object does not exist.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List calls the function for each object whose key has the given prefix. Only the directory
+// of the prefix is walked.
+func (c *Client) List(ctx context.Context, prefix string, f func(key string, lastModified time.Time) error) error {
+	dir := c.root
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		var err error
+		if dir, err = c.path(prefix[:i]); err != nil {
+			return err
+		}
+	}
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir && errors.Is(err, fs.ErrNotExist) {
+				// No object has the prefix.
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(c.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if d.IsDir() {
+			if key == tmpDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return f(key, info.ModTime())
+	})
+}
+
+// Stat returns the size, the ETag, and the last modified time of the object. It returns an error
+// wrapping fs.ErrNotExist if the object does not exist.
+//
+// The ETag is empty as the filesystem does not store it, and computing it requires reading the whole object.
+func (c *Client) Stat(ctx context.Context, key string, enc objectstore.Encryption) (int64, string, time.Time, error) {
+	if err := checkEncryption(enc); err != nil {
+		return 0, "", time.Time{}, err
+	}
+	path, err := c.path(key)
+	if err != nil {
+		return 0, "", time.Time{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, "", time.Time{}, err
+	}
+	if info.IsDir() {
+		return 0, "", time.Time{}, fmt.Errorf("object %q: %w", key, fs.ErrNotExist)
+	}
+	return info.Size(), "", info.ModTime(), nil
+}
+
+// StatBucketObject is not supported as objects in other buckets are not accessible.
+func (c *Client) StatBucketObject(ctx context.Context, bucket, key string) (int64, string, time.Time, error) {
+	return 0, "", time.Time{}, fmt.Errorf("stat bucket object: %w", errors.ErrUnsupported)
+}
+
+// PresignUpload is not supported as the objects are not served over HTTP.
+func (c *Client) PresignUpload(ctx context.Context, key string, enc objectstore.Encryption) (string, time.Time, error) {
+	return "", time.Time{}, fmt.Errorf("presigned URL: %w", errors.ErrUnsupported)
+}
+
+// PresignDownload is not supported as the objects are not served over HTTP.
+func (c *Client) PresignDownload(ctx context.Context, key, filename string, enc objectstore.Encryption) (string, time.Time, error) {
+	return "", time.Time{}, fmt.Errorf("presigned URL: %w", errors.ErrUnsupported)
+}
+
+// CreateMultipartUpload starts a multipart upload and returns its upload ID. Parts are stored in
+// a directory of the upload until the upload is completed.
+func (c *Client) CreateMultipartUpload(ctx context.Context, key string, enc objectstore.Encryption) (string, error) {
+	if err := checkEncryption(enc); err != nil {
+		return "", err
+	}
+	if _, err := c.path(key); err != nil {
+		return "", err
+	}
+	uploadID, err := id.GenerateID("mpu-", 24)
+	if err != nil {
+		return "", err
+	}
+	if err := os.Mkdir(c.uploadDir(uploadID), 0o750); err != nil {
+		return "", err
+	}
+	return uploadID, nil
+}
+
+// UploadPartCopy copies the file of the object to a part of a multipart upload and returns the ETag of the part.
+func (c *Client) UploadPartCopy(ctx context.Context, key, uploadID string, partNumber int32, srcKey string, enc objectstore.Encryption) (string, error) {
+	if err := checkEncryption(enc); err != nil {
+		return "", err
+	}
+	dir := c.uploadDir(uploadID)
+	if _, err := os.Stat(dir); err != nil {
+		return "", fmt.Errorf("multipart upload %q: %w", uploadID, err)
+	}
+	src, err := c.path(srcKey)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	return c.writeFile(filepath.Join(dir, fmt.Sprintf("%d", partNumber)), f)
+}
+
+// CompleteMultipartUpload concatenates the parts in the order of their part numbers and returns
+// the ETag of the object.
+func (c *Client) CompleteMultipartUpload(ctx context.Context, key, uploadID string, partETags map[int32]string, enc objectstore.Encryption) (string, error) {
+	path, err := c.path(key)
+	if err != nil {
+		return "", err
+	}
+	dir := c.uploadDir(uploadID)
+
+	var ns []int32
+	for n := range partETags {
+		ns = append(ns, n)
+	}
+	sort.Slice(ns, func(i, j int) bool { return ns[i] < ns[j] })
+	var rs []io.Reader
+	for _, n := range ns {
+		f, err := os.Open(filepath.Join(dir, fmt.Sprintf("%d", n)))
+		if err != nil {
+			return "", fmt.Errorf("part %d: %w", n, err)
+		}
+		defer func() { _ = f.Close() }()
+		rs = append(rs, f)
+	}
+
+	etag, err := c.writeFile(path, io.MultiReader(rs...))
+	if err != nil {
+		return "", err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+	return etag, nil
+}
+
+// AbortMultipartUpload aborts a multipart upload and deletes its parts.
+func (c *Client) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	return os.RemoveAll(c.uploadDir(uploadID))
+}
+
+// writeFile writes the content to a temporary file and renames it to the path. It returns the ETag
+// of the content.
+func (c *Client) writeFile(path string, r io.Reader) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Join(c.root, tmpDir), "object-")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		// This fails after the file is renamed.
+		_ = os.Remove(tmp.Name())
+	}()
+
+	h := md5.New()
+	if _, err := io.Copy(tmp, io.TeeReader(r, h)); err != nil {
+		_ = tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	return etag(h.Sum(nil)), nil
+}
+
+// path returns the path of the file of the object. It rejects keys that point outside the root directory.
+func (c *Client) path(key string) (string, error) {
+	p := filepath.FromSlash(key)
+	if !filepath.IsLocal(p) || strings.HasPrefix(key, tmpDir+"/") {
+		return "", fmt.Errorf("invalid key: %q", key)
+	}
+	return filepath.Join(c.root, p), nil
+}
+
+func (c *Client) uploadDir(uploadID string) string {
+	return filepath.Join(c.root, tmpDir, filepath.Base(uploadID))
+}
+
+// checkEncryption returns an error if the object is encrypted as server-side encryption is not supported.
+func checkEncryption(enc objectstore.Encryption) error {
+	if enc.Mode != "" {
+		return fmt.Errorf("encryption mode %q: %w", enc.Mode, errors.ErrUnsupported)
+	}
+	return nil
+}
+
+// etag returns the ETag of the content with the MD5 checksum in the same format as S3.
+func etag(sum []byte) string {
+	return fmt.Sprintf("%q", fmt.Sprintf("%x", sum))
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,144 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/objectstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	c, err := NewClient(config.FilesystemConfig{RootDir: t.TempDir()})
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	_, _, _, err = c.Stat(ctx, "prefix/a", objectstore.Encryption{})
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	etag, err := c.Upload(ctx, strings.NewReader("hello"), "prefix/a", objectstore.Encryption{})
+	assert.NoError(t, err)
+	// The MD5 checksum of "hello".
+	assert.Equal(t, `"5d41402abc4b2a76b9719d911017c592"`, etag)
+
+	// The ETag is not recorded.
+	size, gotETag, _, err := c.Stat(ctx, "prefix/a", objectstore.Encryption{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), size)
+	assert.Empty(t, gotETag)
+	_, _, _, err = c.Stat(ctx, "prefix", objectstore.Encryption{})
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	r, err := c.Download(ctx, "prefix/a", 1, 3, objectstore.Encryption{})
+	assert.NoError(t, err)
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.Equal(t, "ell", string(b))
+
+	err = c.Copy(ctx, "prefix/a", "prefix/sub/b", objectstore.Encryption{})
+	assert.NoError(t, err)
+	_, err = c.Upload(ctx, strings.NewReader("other"), "other/c", objectstore.Encryption{})
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "", want: []string{"other/c", "prefix/a", "prefix/sub/b"}},
+		{prefix: "prefix/", want: []string{"prefix/a", "prefix/sub/b"}},
+		{prefix: "prefix/s", want: []string{"prefix/sub/b"}},
+		{prefix: "pre", want: []string{"prefix/a", "prefix/sub/b"}},
+		{prefix: "missing/", want: nil},
+	} {
+		var keys []string
+		err = c.List(ctx, tc.prefix, func(key string, lastModified time.Time) error {
+			keys = append(keys, key)
+			return nil
+		})
+		assert.NoError(t, err, tc.prefix)
+		sort.Strings(keys)
+		assert.Equal(t, tc.want, keys, tc.prefix)
+	}
+
+	assert.NoError(t, c.Delete(ctx, "prefix/a"))
+	// Deleting a missing object is not an error.
+	assert.NoError(t, c.Delete(ctx, "prefix/a"))
+	_, err = c.Download(ctx, "prefix/a", 0, 5, objectstore.Encryption{})
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	// The copy is not affected by the deletion of the source.
+	size, _, _, err = c.Stat(ctx, "prefix/sub/b", objectstore.Encryption{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), size)
+}
+
+func TestClient_InvalidKey(t *testing.T) {
+	c, err := NewClient(config.FilesystemConfig{RootDir: t.TempDir()})
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	for _, key := range []string{"../a", "/a", "prefix/../../a", ".tmp/a", ""} {
+		_, err := c.Upload(ctx, strings.NewReader("hello"), key, objectstore.Encryption{})
+		assert.Error(t, err, key)
+	}
+}
+
+func TestClient_MultipartUpload(t *testing.T) {
+	c, err := NewClient(config.FilesystemConfig{RootDir: t.TempDir()})
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	uploadID, err := c.CreateMultipartUpload(ctx, "prefix/a", objectstore.Encryption{})
+	assert.NoError(t, err)
+
+	// Parts are concatenated in the order of their part numbers.
+	etags := map[int32]string{}
+	for _, p := range []struct {
+		n       int32
+		content string
+	}{
+		{n: 2, content: "llo"},
+		{n: 1, content: "he"},
+	} {
+		src := fmt.Sprintf("parts/%d", p.n)
+		_, err := c.Upload(ctx, strings.NewReader(p.content), src, objectstore.Encryption{})
+		assert.NoError(t, err)
+		etag, err := c.UploadPartCopy(ctx, "prefix/a", uploadID, p.n, src, objectstore.Encryption{})
+		assert.NoError(t, err)
+		etags[p.n] = etag
+	}
+
+	_, err = c.CompleteMultipartUpload(ctx, "prefix/a", uploadID, etags, objectstore.Encryption{})
+	assert.NoError(t, err)
+
+	r, err := c.Download(ctx, "prefix/a", 0, 5, objectstore.Encryption{})
+	assert.NoError(t, err)
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.Equal(t, "hello", string(b))
+
+	// Staged parts are not listed as objects.
+	var keys []string
+	err = c.List(ctx, "prefix/", func(key string, lastModified time.Time) error {
+		keys = append(keys, key)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prefix/a"}, keys)
+
+	uploadID, err = c.CreateMultipartUpload(ctx, "prefix/b", objectstore.Encryption{})
+	assert.NoError(t, err)
+	assert.NoError(t, c.AbortMultipartUpload(ctx, "prefix/b", uploadID))
+	_, err = c.UploadPartCopy(ctx, "prefix/b", uploadID, 1, "parts/1", objectstore.Encryption{})
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
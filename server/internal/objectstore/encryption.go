@@ -0,0 +1,20 @@
+// Package objectstore defines types shared by the object store backends.
+package objectstore
+
+const (
+	// EncryptionModeSSES3 encrypts objects with keys managed by S3 (SSE-S3).
+	EncryptionModeSSES3 = "sse-s3"
+	// EncryptionModeSSEKMS encrypts objects with a key managed by AWS KMS (SSE-KMS).
+	EncryptionModeSSEKMS = "sse-kms"
+	// EncryptionModeSSEC encrypts objects with a key provided by the server (SSE-C). The same key
+	// must be provided to read the objects.
+	EncryptionModeSSEC = "sse-c"
+)
+
+// Encryption specifies the server-side encryption of an object.
+type Encryption struct {
+	// TenantID is the ID of the tenant that owns the object. The keys configured for the tenant are used.
+	TenantID string
+	// Mode is one of the encryption modes. The object is not encrypted by the server if empty.
+	Mode string
+}
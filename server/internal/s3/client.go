@@ -2,17 +2,29 @@ package s3
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"sort"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	laws "github.com/llmariner/common/pkg/aws"
 	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/objectstore"
 )
 
 const (
-	partMiBs int64 = 128
+	defaultUploadPartSizeMiB int64 = 16
+	defaultUploadConcurrency       = 2
+
+	defaultPresignedURLExpiry = 15 * time.Minute
 )
 
 // NewClient returns a new S3 client.
@@ -33,30 +45,332 @@ func NewClient(ctx context.Context, c config.S3Config) (*Client, error) {
 		return nil, err
 	}
 
+	expiry := c.PresignedURLExpiry
+	if expiry == 0 {
+		expiry = defaultPresignedURLExpiry
+	}
+
+	encryption, err := newEncryptionKeys(c.Encryption)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: %s", err)
+	}
+	tenantEncryption := map[string]*encryptionKeys{}
+	for tenantID, ec := range c.TenantEncryption {
+		e, err := newEncryptionKeys(ec)
+		if err != nil {
+			return nil, fmt.Errorf("tenant encryption %q: %s", tenantID, err)
+		}
+		tenantEncryption[tenantID] = e
+	}
+
+	partSizeMiB := c.Upload.PartSizeMiB
+	if partSizeMiB == 0 {
+		partSizeMiB = defaultUploadPartSizeMiB
+	}
+	concurrency := c.Upload.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultUploadConcurrency
+	}
+
 	return &Client{
-		svc:    svc,
-		bucket: c.Bucket,
+		svc:               svc,
+		uploadPartSize:    partSizeMiB * 1024 * 1024,
+		uploadConcurrency: concurrency,
+		presignClient:     s3.NewPresignClient(svc),
+		bucket:            c.Bucket,
+		presignExpiry:     expiry,
+		encryption:        encryption,
+		tenantEncryption:  tenantEncryption,
 	}, nil
 }
 
 // Client is a client for S3.
 type Client struct {
-	svc    *s3.Client
-	bucket string
+	svc           *s3.Client
+	presignClient *s3.PresignClient
+	bucket        string
+	presignExpiry time.Duration
+
+	// uploadPartSize and uploadConcurrency bound the memory used by an upload as the uploader
+	// buffers parts of streamed content.
+	uploadPartSize    int64
+	uploadConcurrency int
+
+	encryption       *encryptionKeys
+	tenantEncryption map[string]*encryptionKeys
 }
 
-// Upload uploads the data that buf contains to a S3 object.
-func (c *Client) Upload(ctx context.Context, r io.Reader, key string) error {
+// EncryptionMode returns the server-side encryption mode applied to new objects of the tenant.
+func (c *Client) EncryptionMode(tenantID string) string {
+	return c.encryptionKeys(tenantID).mode
+}
+
+// Upload uploads the data that buf contains to a S3 object and returns its ETag.
+func (c *Client) Upload(ctx context.Context, r io.Reader, key string, enc objectstore.Encryption) (string, error) {
+	sse, err := c.sse(enc)
+	if err != nil {
+		return "", err
+	}
 	uploader := manager.NewUploader(c.svc, func(u *manager.Uploader) {
-		u.PartSize = partMiBs * 1024 * 1024
+		u.PartSize = c.uploadPartSize
+		u.Concurrency = c.uploadConcurrency
 	})
-	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
-		Bucket: aws.String(c.bucket),
-		Key:    aws.String(key),
-		Body:   r,
+	out, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket:               aws.String(c.bucket),
+		Key:                  aws.String(key),
+		Body:                 r,
+		ServerSideEncryption: sse.serverSideEncryption,
+		SSEKMSKeyId:          sse.kmsKeyID,
+		SSECustomerAlgorithm: sse.customerAlgorithm,
+		SSECustomerKey:       sse.customerKey,
+		SSECustomerKeyMD5:    sse.customerKeyMD5,
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.ETag), nil
+}
+
+// Download returns the content of a S3 object in the given byte range. It returns an error wrapping
+// fs.ErrNotExist if the object does not exist.
+func (c *Client) Download(ctx context.Context, key string, offset, length int64, enc objectstore.Encryption) (io.ReadCloser, error) {
+	sse, err := c.sse(enc)
+	if err != nil {
+		return nil, err
+	}
+	return c.download(ctx, c.bucket, key, offset, length, sse)
+}
+
+// DownloadBucketObject is the same as Download, but for an unencrypted object in the given bucket.
+func (c *Client) DownloadBucketObject(ctx context.Context, bucket, key string, offset, length int64) (io.ReadCloser, error) {
+	return c.download(ctx, bucket, key, offset, length, &sseParams{})
+}
+
+func (c *Client) download(ctx context.Context, bucket, key string, offset, length int64, sse *sseParams) (io.ReadCloser, error) {
+	out, err := c.svc.GetObject(ctx, &s3.GetObjectInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(key),
+		Range:                aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+		SSECustomerAlgorithm: sse.customerAlgorithm,
+		SSECustomerKey:       sse.customerKey,
+		SSECustomerKeyMD5:    sse.customerKeyMD5,
 	})
+	if err != nil {
+		var nsk *types.NoSuchKey
+		if errors.As(err, &nsk) {
+			return nil, fmt.Errorf("object %q: %w", key, fs.ErrNotExist)
+		}
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+// Copy copies a S3 object to another key in the same bucket. The copy is encrypted in the same way as the source.
+func (c *Client) Copy(ctx context.Context, srcKey, dstKey string, enc objectstore.Encryption) error {
+	sse, err := c.sse(enc)
 	if err != nil {
 		return err
 	}
+	_, err = c.svc.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:                         aws.String(c.bucket),
+		CopySource:                     aws.String(c.bucket + "/" + (&url.URL{Path: srcKey}).EscapedPath()),
+		Key:                            aws.String(dstKey),
+		ServerSideEncryption:           sse.serverSideEncryption,
+		SSEKMSKeyId:                    sse.kmsKeyID,
+		SSECustomerAlgorithm:           sse.customerAlgorithm,
+		SSECustomerKey:                 sse.customerKey,
+		SSECustomerKeyMD5:              sse.customerKeyMD5,
+		CopySourceSSECustomerAlgorithm: sse.customerAlgorithm,
+		CopySourceSSECustomerKey:       sse.customerKey,
+		CopySourceSSECustomerKeyMD5:    sse.customerKeyMD5,
+	})
+	return err
+}
+
+// Delete deletes a S3 object.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	_, err := c.svc.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+// List calls the function for each S3 object whose key has the given prefix.
+func (c *Client) List(ctx context.Context, prefix string, f func(key string, lastModified time.Time) error) error {
+	p := s3.NewListObjectsV2Paginator(c.svc, &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, obj := range page.Contents {
+			if err := f(aws.ToString(obj.Key), aws.ToTime(obj.LastModified)); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
+
+// CreateMultipartUpload starts a multipart upload and returns its upload ID.
+func (c *Client) CreateMultipartUpload(ctx context.Context, key string, enc objectstore.Encryption) (string, error) {
+	sse, err := c.sse(enc)
+	if err != nil {
+		return "", err
+	}
+	out, err := c.svc.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:               aws.String(c.bucket),
+		Key:                  aws.String(key),
+		ServerSideEncryption: sse.serverSideEncryption,
+		SSEKMSKeyId:          sse.kmsKeyID,
+		SSECustomerAlgorithm: sse.customerAlgorithm,
+		SSECustomerKey:       sse.customerKey,
+		SSECustomerKeyMD5:    sse.customerKeyMD5,
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.UploadId), nil
+}
+
+// UploadPartCopy copies a S3 object to a part of a multipart upload and returns the ETag of the part.
+func (c *Client) UploadPartCopy(ctx context.Context, key, uploadID string, partNumber int32, srcKey string, enc objectstore.Encryption) (string, error) {
+	sse, err := c.sse(enc)
+	if err != nil {
+		return "", err
+	}
+	out, err := c.svc.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
+		Bucket:                         aws.String(c.bucket),
+		Key:                            aws.String(key),
+		UploadId:                       aws.String(uploadID),
+		PartNumber:                     aws.Int32(partNumber),
+		CopySource:                     aws.String(c.bucket + "/" + (&url.URL{Path: srcKey}).EscapedPath()),
+		SSECustomerAlgorithm:           sse.customerAlgorithm,
+		SSECustomerKey:                 sse.customerKey,
+		SSECustomerKeyMD5:              sse.customerKeyMD5,
+		CopySourceSSECustomerAlgorithm: sse.customerAlgorithm,
+		CopySourceSSECustomerKey:       sse.customerKey,
+		CopySourceSSECustomerKeyMD5:    sse.customerKeyMD5,
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.CopyPartResult.ETag), nil
+}
+
+// CompleteMultipartUpload completes a multipart upload and returns the ETag of the object.
+func (c *Client) CompleteMultipartUpload(ctx context.Context, key, uploadID string, partETags map[int32]string, enc objectstore.Encryption) (string, error) {
+	sse, err := c.sse(enc)
+	if err != nil {
+		return "", err
+	}
+	var parts []types.CompletedPart
+	for n, etag := range partETags {
+		parts = append(parts, types.CompletedPart{
+			PartNumber: aws.Int32(n),
+			ETag:       aws.String(etag),
+		})
+	}
+	// Parts must be sorted by their part numbers.
+	sort.Slice(parts, func(i, j int) bool {
+		return aws.ToInt32(parts[i].PartNumber) < aws.ToInt32(parts[j].PartNumber)
+	})
+	out, err := c.svc.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(c.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: parts,
+		},
+		SSECustomerAlgorithm: sse.customerAlgorithm,
+		SSECustomerKey:       sse.customerKey,
+		SSECustomerKeyMD5:    sse.customerKeyMD5,
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.ETag), nil
+}
+
+// Stat returns the size, the ETag, and the last modified time of a S3 object. It returns an error
+// wrapping fs.ErrNotExist if the object does not exist.
+func (c *Client) Stat(ctx context.Context, key string, enc objectstore.Encryption) (int64, string, time.Time, error) {
+	sse, err := c.sse(enc)
+	if err != nil {
+		return 0, "", time.Time{}, err
+	}
+	return c.stat(ctx, c.bucket, key, sse)
+}
+
+// StatBucketObject is the same as Stat, but for an unencrypted object in the given bucket.
+func (c *Client) StatBucketObject(ctx context.Context, bucket, key string) (int64, string, time.Time, error) {
+	return c.stat(ctx, bucket, key, &sseParams{})
+}
+
+func (c *Client) stat(ctx context.Context, bucket, key string, sse *sseParams) (int64, string, time.Time, error) {
+	out, err := c.svc.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(key),
+		SSECustomerAlgorithm: sse.customerAlgorithm,
+		SSECustomerKey:       sse.customerKey,
+		SSECustomerKeyMD5:    sse.customerKeyMD5,
+	})
+	if err != nil {
+		var nf *types.NotFound
+		if errors.As(err, &nf) {
+			return 0, "", time.Time{}, fmt.Errorf("object %q: %w", key, fs.ErrNotExist)
+		}
+		return 0, "", time.Time{}, err
+	}
+	return aws.ToInt64(out.ContentLength), aws.ToString(out.ETag), aws.ToTime(out.LastModified), nil
+}
+
+// PresignUpload returns a presigned URL to upload a S3 object with a PUT request and the expiration time of the URL.
+// Encrypted objects are not supported as the client would need to send the encryption headers.
+func (c *Client) PresignUpload(ctx context.Context, key string, enc objectstore.Encryption) (string, time.Time, error) {
+	if enc.Mode != "" {
+		return "", time.Time{}, fmt.Errorf("presigned upload URL with %s: %w", enc.Mode, errors.ErrUnsupported)
+	}
+	expiresAt := time.Now().Add(c.presignExpiry)
+	req, err := c.presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(c.presignExpiry))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return req.URL, expiresAt, nil
+}
+
+// PresignDownload returns a presigned URL to download a S3 object with a GET request and the expiration time of the URL.
+// The response is served as an attachment with the given filename. Objects encrypted with SSE-C are not supported
+// as the client would need to send the key.
+func (c *Client) PresignDownload(ctx context.Context, key, filename string, enc objectstore.Encryption) (string, time.Time, error) {
+	if enc.Mode == objectstore.EncryptionModeSSEC {
+		return "", time.Time{}, fmt.Errorf("presigned download URL with %s: %w", enc.Mode, errors.ErrUnsupported)
+	}
+	expiresAt := time.Now().Add(c.presignExpiry)
+	req, err := c.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket:                     aws.String(c.bucket),
+		Key:                        aws.String(key),
+		ResponseContentDisposition: aws.String(mime.FormatMediaType("attachment", map[string]string{"filename": filename})),
+	}, s3.WithPresignExpires(c.presignExpiry))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return req.URL, expiresAt, nil
+}
+
+// AbortMultipartUpload aborts a multipart upload.
+func (c *Client) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	_, err := c.svc.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(c.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	return err
+}
@@ -0,0 +1,99 @@
+package s3
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/objectstore"
+)
+
+// sseCustomerAlgorithm is the only algorithm supported with SSE-C.
+const sseCustomerAlgorithm = "AES256"
+
+// encryptionKeys is the server-side encryption configuration of a tenant with the loaded keys.
+type encryptionKeys struct {
+	mode     string
+	kmsKeyID string
+	// customerKey and customerKeyMD5 are the base64-encoded key used with SSE-C and its MD5 checksum.
+	customerKey    string
+	customerKeyMD5 string
+}
+
+func newEncryptionKeys(c config.EncryptionConfig) (*encryptionKeys, error) {
+	e := &encryptionKeys{
+		mode:     c.Mode,
+		kmsKeyID: c.KMSKeyID,
+	}
+	if c.Mode != objectstore.EncryptionModeSSEC {
+		return e, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(c.CustomerKey())
+	if err != nil {
+		return nil, fmt.Errorf("decode customer key: %s", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("customer key must be 256 bits, but got %d bits", len(key)*8)
+	}
+	sum := md5.Sum(key)
+	e.customerKey = base64.StdEncoding.EncodeToString(key)
+	e.customerKeyMD5 = base64.StdEncoding.EncodeToString(sum[:])
+	return e, nil
+}
+
+// sseParams are the parameters of S3 requests for server-side encryption. Fields are nil if not applicable.
+type sseParams struct {
+	serverSideEncryption types.ServerSideEncryption
+	kmsKeyID             *string
+	customerAlgorithm    *string
+	customerKey          *string
+	customerKeyMD5       *string
+}
+
+func (c *Client) encryptionKeys(tenantID string) *encryptionKeys {
+	if e, ok := c.tenantEncryption[tenantID]; ok {
+		return e
+	}
+	return c.encryption
+}
+
+// sse returns the parameters for the encryption. The keys configured for the tenant are used.
+//
+// The mode of the encryption can be different from the mode currently configured for the tenant
+// when the object was created before the configuration changed. Objects encrypted with SSE-S3 and SSE-KMS
+// can be read without keys, but objects encrypted with SSE-C cannot be read if the tenant no longer has
+// a customer key.
+func (c *Client) sse(enc objectstore.Encryption) (*sseParams, error) {
+	keys := c.encryptionKeys(enc.TenantID)
+	switch enc.Mode {
+	case "":
+		return &sseParams{}, nil
+	case objectstore.EncryptionModeSSES3:
+		return &sseParams{
+			serverSideEncryption: types.ServerSideEncryptionAes256,
+		}, nil
+	case objectstore.EncryptionModeSSEKMS:
+		p := &sseParams{
+			serverSideEncryption: types.ServerSideEncryptionAwsKms,
+		}
+		if keys.mode == objectstore.EncryptionModeSSEKMS && keys.kmsKeyID != "" {
+			p.kmsKeyID = aws.String(keys.kmsKeyID)
+		}
+		return p, nil
+	case objectstore.EncryptionModeSSEC:
+		if keys.customerKey == "" {
+			return nil, fmt.Errorf("no customer key for tenant %q", enc.TenantID)
+		}
+		return &sseParams{
+			customerAlgorithm: aws.String(sseCustomerAlgorithm),
+			customerKey:       aws.String(keys.customerKey),
+			customerKeyMD5:    aws.String(keys.customerKeyMD5),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown encryption mode %q", enc.Mode)
+	}
+}
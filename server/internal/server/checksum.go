@@ -0,0 +1,39 @@
+package server
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+)
+
+// checksums is an io.Writer that computes the checksums of the written content.
+type checksums struct {
+	sha256 hash.Hash
+	md5    hash.Hash
+}
+
+func newChecksums() *checksums {
+	return &checksums{
+		sha256: sha256.New(),
+		md5:    md5.New(),
+	}
+}
+
+// Write implements io.Writer.
+func (c *checksums) Write(p []byte) (int, error) {
+	// hash.Hash never returns an error.
+	_, _ = c.sha256.Write(p)
+	_, _ = c.md5.Write(p)
+	return len(p), nil
+}
+
+// sha256Hex returns the hex-encoded SHA-256 checksum.
+func (c *checksums) sha256Hex() string {
+	return hex.EncodeToString(c.sha256.Sum(nil))
+}
+
+// md5Hex returns the hex-encoded MD5 checksum.
+func (c *checksums) md5Hex() string {
+	return hex.EncodeToString(c.md5.Sum(nil))
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	auv1 "github.com/llmariner/api-usage/api/v1"
+	"github.com/llmariner/file-manager/server/internal/objectstore"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/llmariner/file-manager/server/internal/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorJSON is an error object in the OpenAI API spec.
+type errorJSON struct {
+	Message string  `json:"message"`
+	Type    string  `json:"type"`
+	Param   *string `json:"param"`
+	Code    string  `json:"code"`
+	// Line is the line number of the first error found in the content of a file.
+	Line *int `json:"line,omitempty"`
+}
+
+type errorResponseJSON struct {
+	Error *errorJSON `json:"error"`
+}
+
+// validateRegisteredObject validates the content of an object registered with CreateFileFromObjectPath.
+func (s *S) validateRegisteredObject(
+	ctx context.Context,
+	rule *purposeRule,
+	bucket string,
+	key string,
+	bytes int64,
+) (*validation.JSONLValidator, error) {
+	cv := rule.newContentValidator()
+	if bytes > 0 {
+		r, err := s.objectStore.DownloadBucketObject(ctx, bucket, key, 0, bytes)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = r.Close()
+		}()
+		if _, err := io.Copy(cv, r); err != nil && !errors.Is(err, validation.ErrTooManyErrors) {
+			return nil, err
+		}
+	}
+	cv.Flush()
+	return cv, nil
+}
+
+// validateFileContent validates the content of an existing file for the purpose of the rule. It returns nil
+// if the content is not validated for the purpose. Objects registered with CreateFileFromObjectPath are
+// validated only if they have been verified and the purpose validates registered objects.
+func (s *S) validateFileContent(ctx context.Context, rule *purposeRule, f *store.File) (*validation.JSONLValidator, error) {
+	if rule.newLineValidator == nil {
+		return nil, nil
+	}
+	if isExternalObjectPath(f.ObjectStorePath) {
+		if !rule.validateRegisteredObjects || f.ObjectVerificationStatus != store.ObjectVerificationStatusVerified {
+			return nil, nil
+		}
+		bucket, key, ok := parseExternalObjectPath(f.ObjectStorePath)
+		if !ok {
+			return nil, fmt.Errorf("invalid object path: %q", f.ObjectStorePath)
+		}
+		return s.validateRegisteredObject(ctx, rule, bucket, key, f.Bytes)
+	}
+
+	cv, _, err := s.scanUploadedObject(ctx, rule, f.ObjectStorePath, f.Bytes, fileEncryption(f))
+	return cv, err
+}
+
+// scanUploadedObject reads an object uploaded to the object store by a client. It validates the content for
+// the purpose of the rule and computes the checksums of the content. The returned validator is nil if the content
+// is not validated for the purpose. The checksums are incomplete if the validator finds too many errors.
+//
+// This is used for the files whose content is not streamed through the server (e.g., multipart uploads)
+// so that they are subject to the same validation as files uploaded with CreateFile.
+func (s *S) scanUploadedObject(
+	ctx context.Context,
+	rule *purposeRule,
+	path string,
+	bytes int64,
+	enc objectstore.Encryption,
+) (*validation.JSONLValidator, *checksums, error) {
+	sums := newChecksums()
+	var w io.Writer = sums
+	cv := rule.newContentValidator()
+	if cv != nil {
+		w = io.MultiWriter(sums, cv)
+	}
+	r := newObjectReader(ctx, s.objectStore, path, bytes, enc)
+	defer func() {
+		_ = r.Close()
+	}()
+	if _, err := io.Copy(w, r); err != nil && !errors.Is(err, validation.ErrTooManyErrors) {
+		return nil, nil, err
+	}
+	if cv != nil {
+		cv.Flush()
+	}
+	return cv, sums, nil
+}
+
+// contentValidationError returns a gRPC error that contains the errors found in the content of a file.
+func contentValidationError(purpose string, cv *validation.JSONLValidator) error {
+	st := status.New(codes.InvalidArgument, invalidContentMessage(purpose, cv))
+	br := &errdetails.BadRequest{}
+	for _, e := range cv.Errors() {
+		field := "file"
+		if e.Line > 0 {
+			field = fmt.Sprintf("file:line %d", e.Line)
+		}
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: e.Message,
+		})
+	}
+	if sd, err := st.WithDetails(br); err == nil {
+		st = sd
+	}
+	return st.Err()
+}
+
+// httpContentValidationError writes an OpenAI-style error object that contains the errors found in the content of a file.
+func httpContentValidationError(w http.ResponseWriter, purpose string, cv *validation.JSONLValidator, usage *auv1.UsageRecord) {
+	param := "file"
+	ej := &errorJSON{
+		Message: invalidContentMessage(purpose, cv),
+		Type:    "invalid_request_error",
+		Param:   &param,
+		Code:    "invalid_file_content",
+	}
+	if errs := cv.Errors(); len(errs) > 0 && errs[0].Line > 0 {
+		ej.Line = &errs[0].Line
+	}
+	b, err := json.Marshal(&errorResponseJSON{Error: ej})
+	if err != nil {
+		httpError(w, err.Error(), http.StatusInternalServerError, usage)
+		return
+	}
+	usage.StatusCode = http.StatusBadRequest
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = w.Write(b)
+}
+
+func invalidContentMessage(purpose string, cv *validation.JSONLValidator) string {
+	return fmt.Sprintf("invalid %s file: %s", purpose, cv.Summary())
+}
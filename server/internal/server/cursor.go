@@ -0,0 +1,70 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/llmariner/file-manager/server/internal/store"
+	"gorm.io/gorm"
+)
+
+// fileCursor is the position of the last file of a page of ListFiles. It holds the value of the sort key
+// and the internal ID of the file as the tiebreaker so that the next page can be retrieved even after
+// the file is deleted.
+type fileCursor struct {
+	SortKey  store.FileSortKey `json:"k"`
+	Order    string            `json:"o"`
+	ID       uint              `json:"i"`
+	Filename string            `json:"f,omitempty"`
+	Bytes    int64             `json:"b,omitempty"`
+}
+
+func newFileCursor(f *store.File, sortKey store.FileSortKey, order string) *fileCursor {
+	c := &fileCursor{
+		SortKey: sortKey,
+		Order:   order,
+		ID:      f.ID,
+	}
+	switch sortKey {
+	case store.FileSortKeyFilename:
+		c.Filename = f.Filename
+	case store.FileSortKeyBytes:
+		c.Bytes = f.Bytes
+	}
+	return c
+}
+
+// encode returns the opaque string representation of the cursor.
+func (c *fileCursor) encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// file returns the file used as the position of the cursor in the store.
+func (c *fileCursor) file() *store.File {
+	return &store.File{
+		Model:    gorm.Model{ID: c.ID},
+		Filename: c.Filename,
+		Bytes:    c.Bytes,
+	}
+}
+
+// decodeFileCursor decodes the cursor and checks that it has been created for the sort key and the order.
+func decodeFileCursor(s string, sortKey store.FileSortKey, order string) (*fileCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	var c fileCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	if c.SortKey != sortKey || c.Order != order {
+		return nil, fmt.Errorf("cursor was created for sort_by %q and order %q", c.SortKey, c.Order)
+	}
+	return &c, nil
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/llmariner/file-manager/server/internal/objectstore"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// createDeduplicatedFile creates a file whose object is shared with other files having the same content
+// in the tenant. The object of the spec is the uploaded object. It is deleted if an object with the same
+// content exists. Otherwise it is copied to a path keyed by the checksum.
+func (s *S) createDeduplicatedFile(ctx context.Context, spec store.FileSpec) (*store.File, error) {
+	uploadedPath := spec.ObjectStorePath
+
+	f, err := s.createFileWithBlob(spec, nil)
+	if err == nil {
+		s.enqueueObjectDeletion(uploadedPath)
+		return f, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Include the file ID in the path so that a new object never overwrites an object whose last
+	// reference has been just released.
+	blobPath := s.filePath(fmt.Sprintf("blobs/%s/%s/%s", spec.TenantID, spec.SHA256, spec.FileID))
+	enc := objectstore.Encryption{
+		TenantID: spec.TenantID,
+		Mode:     spec.EncryptionMode,
+	}
+	if err := s.objectStore.Copy(ctx, uploadedPath, blobPath, enc); err != nil {
+		if !errors.Is(err, errors.ErrUnsupported) {
+			s.log.Error(err, "Failed to copy the object. Using the uploaded object as the shared object", "path", uploadedPath)
+		}
+		blobPath = uploadedPath
+	}
+
+	f, err = s.createFileWithBlob(spec, &store.Blob{
+		TenantID:        spec.TenantID,
+		SHA256:          spec.SHA256,
+		Bytes:           spec.Bytes,
+		ObjectStorePath: blobPath,
+		EncryptionMode:  spec.EncryptionMode,
+	})
+	if err != nil {
+		if blobPath != uploadedPath {
+			s.enqueueObjectDeletion(blobPath)
+		}
+		return nil, err
+	}
+	if f.ObjectStorePath != uploadedPath {
+		s.enqueueObjectDeletion(uploadedPath)
+	}
+	if f.ObjectStorePath != blobPath && blobPath != uploadedPath {
+		// An object with the same content has been created concurrently.
+		s.enqueueObjectDeletion(blobPath)
+	}
+	return f, nil
+}
+
+// createFileWithBlob creates a file that references the blob having the same content in a transaction.
+// If no blob has the content, the given blob is created. It returns gorm.ErrRecordNotFound if no blob
+// has the content and no blob is given.
+func (s *S) createFileWithBlob(spec store.FileSpec, newBlob *store.Blob) (*store.File, error) {
+	var f *store.File
+	if err := s.store.Transaction(func(tx *gorm.DB) error {
+		b, err := store.AcquireBlobInTransaction(tx, spec.TenantID, spec.SHA256, spec.EncryptionMode)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) || newBlob == nil {
+				return err
+			}
+			created, err := store.CreateBlobInTransaction(tx, newBlob)
+			if err != nil {
+				return err
+			}
+			if created {
+				b = newBlob
+			} else if b, err = store.AcquireBlobInTransaction(tx, spec.TenantID, spec.SHA256, spec.EncryptionMode); err != nil {
+				return err
+			}
+		}
+		spec.ObjectStorePath = b.ObjectStorePath
+
+		if err := s.checkQuotaInTransaction(tx, spec.TenantID, spec.ProjectID, spec.Bytes); err != nil {
+			return err
+		}
+		f, err = store.CreateFileInTransaction(tx, spec)
+		return err
+	}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "create file: %s", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/llmariner/api-usage/pkg/sender"
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/llmariner/rbac-manager/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestDeduplication(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, true, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	upload := func(tenantID, projectID, content string) *store.File {
+		srv.reqIntercepter = &fakeReqIntercepter{tenantID: tenantID, projectID: projectID}
+		var b bytes.Buffer
+		w := multipart.NewWriter(&b)
+		err := w.WriteField("purpose", purposeAssistants)
+		assert.NoError(t, err)
+		fw, err := w.CreateFormFile("file", "test.txt")
+		assert.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		assert.NoError(t, err)
+		err = w.Close()
+		assert.NoError(t, err)
+		req, err := http.NewRequest("POST", "v1/files", &b)
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		rr := httptest.NewRecorder()
+		srv.CreateFile(rr, req, nil)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var fj fileJSON
+		err = json.Unmarshal(rr.Body.Bytes(), &fj)
+		assert.NoError(t, err)
+		f, err := st.GetFileByFileID(fj.ID)
+		assert.NoError(t, err)
+		return f
+	}
+
+	f0 := upload(defaultTenantID, defaultProjectID, "hello")
+	assert.True(t, strings.HasPrefix(f0.ObjectStorePath, "pathPrefix/blobs/"+defaultTenantID+"/"+helloSHA256+"/"), f0.ObjectStorePath)
+	assert.Equal(t, "hello", string(objectStore.objs[f0.ObjectStorePath]))
+
+	// Files with the same content share the object in the tenant, even across projects.
+	f1 := upload(defaultTenantID, "other-project", "hello")
+	assert.Equal(t, f0.ObjectStorePath, f1.ObjectStorePath)
+	f2 := upload(defaultTenantID, defaultProjectID, "world")
+	assert.NotEqual(t, f0.ObjectStorePath, f2.ObjectStorePath)
+
+	// Objects are not shared across tenants.
+	f3 := upload("other-tenant", "other-project", "hello")
+	assert.NotEqual(t, f0.ObjectStorePath, f3.ObjectStorePath)
+
+	b, err := st.GetBlobByObjectStorePath(f0.ObjectStorePath)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), b.RefCount)
+
+	// Only the uploaded objects are enqueued for deletion.
+	ds, err := st.ListDueObjectDeletions(time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 4)
+	for _, d := range ds {
+		assert.False(t, strings.HasPrefix(d.ObjectStorePath, "pathPrefix/blobs/"), d.ObjectStorePath)
+		assert.NoError(t, st.DeleteObjectDeletion(d.ID))
+	}
+
+	// The object is kept until the last file is deleted.
+	_, err = srv.DeleteFile(ctx, &v1.DeleteFileRequest{Id: f0.FileID})
+	assert.NoError(t, err)
+	ds, err = st.ListDueObjectDeletions(time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, ds)
+
+	srv.reqIntercepter = noopReqIntercepter{}
+	_, err = srv.DeleteFile(auth.AppendUserInfoToContext(context.Background(), auth.UserInfo{
+		TenantID:  defaultTenantID,
+		ProjectID: "other-project",
+	}), &v1.DeleteFileRequest{Id: f1.FileID})
+	assert.NoError(t, err)
+	ds, err = st.ListDueObjectDeletions(time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 1)
+	assert.Equal(t, f0.ObjectStorePath, ds[0].ObjectStorePath)
+	_, err = st.GetBlobByObjectStorePath(f0.ObjectStorePath)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	// A new object is created for the same content after the last reference is released.
+	f4 := upload(defaultTenantID, defaultProjectID, "hello")
+	assert.NotEqual(t, f0.ObjectStorePath, f4.ObjectStorePath)
+}
+
+func TestDeduplicationWithoutCopy(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	srv := New(st, &NoopObjectStore{}, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, true, testr.New(t))
+
+	// The uploaded object is shared when the object store does not support copying objects.
+	var paths []string
+	for i := 0; i < 2; i++ {
+		var b bytes.Buffer
+		w := multipart.NewWriter(&b)
+		err := w.WriteField("purpose", purposeAssistants)
+		assert.NoError(t, err)
+		fw, err := w.CreateFormFile("file", "test.txt")
+		assert.NoError(t, err)
+		_, err = fw.Write([]byte("hello"))
+		assert.NoError(t, err)
+		err = w.Close()
+		assert.NoError(t, err)
+		req, err := http.NewRequest("POST", "v1/files", &b)
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		rr := httptest.NewRecorder()
+		srv.CreateFile(rr, req, nil)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var fj fileJSON
+		err = json.Unmarshal(rr.Body.Bytes(), &fj)
+		assert.NoError(t, err)
+		f, err := st.GetFileByFileID(fj.ID)
+		assert.NoError(t, err)
+		paths = append(paths, f.ObjectStorePath)
+	}
+	assert.Equal(t, paths[0], paths[1])
+	assert.False(t, strings.HasPrefix(paths[0], "pathPrefix/blobs/"), paths[0])
+
+	// Only the object uploaded for the second file is deleted.
+	ds, err := st.ListDueObjectDeletions(time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 1)
+	assert.NotEqual(t, paths[0], ds[0].ObjectStorePath)
+}
+
+type fakeReqIntercepter struct {
+	tenantID  string
+	projectID string
+}
+
+func (i *fakeReqIntercepter) InterceptHTTPRequest(req *http.Request) (int, auth.UserInfo, error) {
+	return http.StatusOK, auth.UserInfo{
+		TenantID:  i.tenantID,
+		ProjectID: i.projectID,
+	}, nil
+}
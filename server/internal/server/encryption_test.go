@@ -0,0 +1,108 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/llmariner/api-usage/pkg/sender"
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/objectstore"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryption(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	objectStore.encryptionModes = map[string]string{
+		defaultTenantID: objectstore.EncryptionModeSSEC,
+	}
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, true, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	upload := func(content string) *store.File {
+		var b bytes.Buffer
+		w := multipart.NewWriter(&b)
+		err := w.WriteField("purpose", purposeAssistants)
+		assert.NoError(t, err)
+		fw, err := w.CreateFormFile("file", "test.txt")
+		assert.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		assert.NoError(t, err)
+		err = w.Close()
+		assert.NoError(t, err)
+		req, err := http.NewRequest("POST", "v1/files", &b)
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		rr := httptest.NewRecorder()
+		srv.CreateFile(rr, req, nil)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var fj fileJSON
+		err = json.Unmarshal(rr.Body.Bytes(), &fj)
+		assert.NoError(t, err)
+		f, err := st.GetFileByFileID(fj.ID)
+		assert.NoError(t, err)
+		return f
+	}
+	getContent := func(f *store.File) string {
+		req, err := http.NewRequest("GET", "v1/files/"+f.FileID+"/content", nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		srv.GetFileContent(rr, req, map[string]string{"id": f.FileID})
+		assert.Equal(t, http.StatusOK, rr.Code)
+		return rr.Body.String()
+	}
+
+	f0 := upload("hello")
+	assert.Equal(t, objectstore.EncryptionModeSSEC, f0.EncryptionMode)
+	assert.Equal(t, objectstore.EncryptionModeSSEC, objectStore.objEncryptions[f0.ObjectStorePath].Mode)
+	assert.Equal(t, "hello", getContent(f0))
+
+	wsrv := NewWorkerServiceServer(st, testr.New(t))
+	resp, err := wsrv.GetFilePath(ctx, &v1.GetFilePathRequest{Id: f0.FileID})
+	assert.NoError(t, err)
+	assert.Equal(t, objectstore.EncryptionModeSSEC, resp.EncryptionMode)
+
+	// An upload started before the configuration changes is completed with the same mode.
+	u, err := srv.CreateUpload(ctx, &v1.CreateUploadRequest{
+		Filename: "test.txt",
+		Purpose:  purposeAssistants,
+		Bytes:    5,
+	})
+	assert.NoError(t, err)
+
+	objectStore.encryptionModes[defaultTenantID] = objectstore.EncryptionModeSSEKMS
+
+	// Existing objects are read with the mode recorded for the file.
+	assert.Equal(t, "hello", getContent(f0))
+
+	code, p := addUploadPart(t, srv, u.Id, "world")
+	assert.Equal(t, http.StatusOK, code)
+	got, err := srv.CompleteUpload(ctx, &v1.CompleteUploadRequest{
+		Id:      u.Id,
+		PartIds: []string{p.ID},
+	})
+	assert.NoError(t, err)
+	f1, err := st.GetFileByFileID(got.File.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, objectstore.EncryptionModeSSEC, f1.EncryptionMode)
+	assert.Equal(t, "world", getContent(f1))
+
+	// The object is not shared with a file having the same content, but encrypted with another mode.
+	f2 := upload("hello")
+	assert.Equal(t, objectstore.EncryptionModeSSEKMS, f2.EncryptionMode)
+	assert.NotEqual(t, f0.ObjectStorePath, f2.ObjectStorePath)
+	assert.Equal(t, "hello", getContent(f2))
+
+	f3 := upload("hello")
+	assert.Equal(t, f2.ObjectStorePath, f3.ObjectStorePath)
+}
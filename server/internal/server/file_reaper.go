@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"gorm.io/gorm"
+)
+
+// fileReaperBatchSize is the maximum number of files deleted in a single run.
+const fileReaperBatchSize = 100
+
+// NewFileReaper creates a new file reaper.
+func NewFileReaper(st *store.S, log logr.Logger) *FileReaper {
+	return &FileReaper{
+		store: st,
+		log:   log.WithName("file-reaper"),
+	}
+}
+
+// FileReaper deletes expired files. The deletion of their objects is enqueued and
+// done by the object deleter.
+type FileReaper struct {
+	store *store.S
+	log   logr.Logger
+}
+
+// Run periodically deletes expired files.
+func (r *FileReaper) Run(ctx context.Context, interval time.Duration) error {
+	r.log.Info("Starting file reaper...", "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := r.reapFiles(time.Now()); err != nil {
+			r.log.Error(err, "Failed to delete expired files")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *FileReaper) reapFiles(now time.Time) error {
+	for {
+		fs, err := r.store.ListExpiredFiles(now, fileReaperBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, f := range fs {
+			if err := deleteFile(r.store, f); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					// The file has been deleted concurrently.
+					continue
+				}
+				return err
+			}
+			r.log.Info("Deleted the expired file", "fileID", f.FileID)
+		}
+		if len(fs) < fileReaperBatchSize {
+			return nil
+		}
+	}
+}
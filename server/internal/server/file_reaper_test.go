@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/llmariner/api-usage/pkg/sender"
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestReapFiles(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := &memoryObjectStore{objs: map[string][]byte{
+		"s3://bucket/f1.jsonl": []byte("hello"),
+		"s3://bucket/f2.jsonl": []byte("hello"),
+	}}
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	now := time.Now()
+	_, err := st.CreateFile(store.FileSpec{
+		FileID:          "f0",
+		TenantID:        defaultTenantID,
+		ProjectID:       defaultProjectID,
+		ObjectStorePath: "pathPrefix/f0",
+		ExpiresAt:       now.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+
+	f1, err := srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/f1.jsonl",
+		Purpose:    purposeAssistants,
+		ExpiresAfter: &v1.ExpiresAfter{
+			Anchor:  "created_at",
+			Seconds: 2 * 3600,
+		},
+	})
+	assert.NoError(t, err)
+	assert.NotZero(t, f1.ExpiresAt)
+
+	// A file without an expiration policy.
+	f2, err := srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/f2.jsonl",
+		Purpose:    purposeAssistants,
+	})
+	assert.NoError(t, err)
+	assert.Zero(t, f2.ExpiresAt)
+
+	_, err = srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/f2.jsonl",
+		Purpose:    purposeAssistants,
+		ExpiresAfter: &v1.ExpiresAfter{
+			Anchor:  "created_at",
+			Seconds: 31 * 24 * 3600,
+		},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	r := NewFileReaper(st, testr.New(t))
+	err = r.reapFiles(now)
+	assert.NoError(t, err)
+	fs, err := st.ListFilesByProjectID(defaultProjectID)
+	assert.NoError(t, err)
+	assert.Len(t, fs, 3)
+
+	err = r.reapFiles(now.Add(time.Hour))
+	assert.NoError(t, err)
+	_, err = st.GetFileByFileID("f0")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	err = r.reapFiles(now.Add(3 * time.Hour))
+	assert.NoError(t, err)
+	fs, err = st.ListFilesByProjectID(defaultProjectID)
+	assert.NoError(t, err)
+	assert.Len(t, fs, 1)
+	assert.Equal(t, f2.Id, fs[0].FileID)
+
+	// Only the object uploaded by file-manager is deleted.
+	ds, err := st.ListDueObjectDeletions(now.Add(3*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 1)
+	assert.Equal(t, "pathPrefix/f0", ds[0].ObjectStorePath)
+}
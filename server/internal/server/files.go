@@ -5,15 +5,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	auv1 "github.com/llmariner/api-usage/api/v1"
 	"github.com/llmariner/common/pkg/id"
 	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/objectstore"
 	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/llmariner/file-manager/server/internal/validation"
 	"github.com/llmariner/rbac-manager/pkg/auth"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -21,10 +29,21 @@ import (
 )
 
 const (
-	purposeFineTune   = "fine-tune"
-	purposeAssistants = "assistants"
-	defaultPageSize   = 20
-	maxPageSize       = 100
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	// maxFormValueBytes is the maximum size of a non-file form value in a multipart request.
+	maxFormValueBytes = 1024
+
+	// objectVerificationTimeout is the timeout of the lookup of an object registered with CreateFileFromObjectPath.
+	objectVerificationTimeout = 5 * time.Second
+
+	fileUploadDisabledMsg = "file upload is disabled. use CreateFileFromObjectPath to create a file from an existing object"
+
+	// expiresAfterAnchorCreatedAt is the only supported anchor of the expiration policy.
+	expiresAfterAnchorCreatedAt = "created_at"
+	minExpiresAfter             = time.Hour
+	maxExpiresAfter             = 30 * 24 * time.Hour
 )
 
 // CreateFile creates a file.
@@ -54,67 +73,248 @@ func (s *S) CreateFile(
 		s.usage.AddUsage(&usage)
 	}()
 
-	if err := req.ParseMultipartForm(10 << 20); err != nil {
-		httpError(w, err.Error(), http.StatusBadRequest, &usage)
+	if !s.enableFileUpload {
+		httpError(w, fileUploadDisabledMsg, http.StatusForbidden, &usage)
 		return
 	}
-
-	purpose := req.FormValue("purpose")
-	if purpose == "" {
-		httpError(w, "purpose is required", http.StatusBadRequest, &usage)
+	// Reject the file before uploading its content if the quotas have been already exhausted.
+	if err := s.checkQuota(userInfo.TenantID, userInfo.ProjectID, 0); err != nil {
+		httpError(w, statusMessage(err), httpStatusCode(err), &usage)
 		return
 	}
-	if err := validatePurpose(purpose); err != nil {
+
+	// Walk the multipart stream instead of parsing the entire form so that the file content is
+	// piped to the object store without being buffered in memory or on the local disk.
+	mr, err := req.MultipartReader()
+	if err != nil {
 		httpError(w, err.Error(), http.StatusBadRequest, &usage)
 		return
 	}
 
-	// Read the file content.
-	file, header, err := req.FormFile("file")
-	if err != nil {
-		if err == http.ErrMissingFile {
-			http.Error(w, "file is required", http.StatusBadRequest)
+	var (
+		purpose  string
+		rule     *purposeRule
+		cv       *validation.JSONLValidator
+		filename string
+		fileID   string
+		path     string
+		etag     string
+		bytes    int64
+		sums     *checksums
+		enc      = s.newObjectEncryption(userInfo.TenantID)
+		// uploadedBeforePurpose is true if the file is uploaded before the purpose is given.
+		uploadedBeforePurpose bool
+
+		expiresAfterAnchor  string
+		expiresAfterSeconds string
+
+		metadata = map[string]string{}
+	)
+	// abort enqueues the deletion of the uploaded object and returns an error.
+	abort := func(msg string, code int) {
+		if path != "" {
+			s.enqueueObjectDeletion(path)
+		}
+		httpError(w, msg, code, &usage)
+	}
+	// abortInvalidContent enqueues the deletion of the uploaded object and returns the errors found in the content.
+	abortInvalidContent := func() {
+		s.enqueueObjectDeletion(path)
+		httpContentValidationError(w, purpose, cv, &usage)
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			abort(err.Error(), http.StatusBadRequest)
 			return
 		}
-		httpError(w, err.Error(), http.StatusBadRequest, &usage)
-		return
+
+		switch part.FormName() {
+		case "purpose":
+			if purpose, err = readFormValue(part); err != nil {
+				abort(err.Error(), http.StatusBadRequest)
+				return
+			}
+			if rule, err = validateUploadPurpose(purpose); err != nil {
+				abort(err.Error(), http.StatusBadRequest)
+				return
+			}
+		case "expires_after[anchor]":
+			if expiresAfterAnchor, err = readFormValue(part); err != nil {
+				abort(err.Error(), http.StatusBadRequest)
+				return
+			}
+		case "expires_after[seconds]":
+			if expiresAfterSeconds, err = readFormValue(part); err != nil {
+				abort(err.Error(), http.StatusBadRequest)
+				return
+			}
+		case "file":
+			if path != "" {
+				abort("only one file can be uploaded", http.StatusBadRequest)
+				return
+			}
+
+			fileID, err = id.GenerateID("file-", 24)
+			if err != nil {
+				abort(fmt.Sprintf("generate file id: %s", err.Error()), http.StatusInternalServerError)
+				return
+			}
+			filename = part.FileName()
+			// Validate the file for the purpose while it is streamed if the purpose has been given.
+			// Otherwise, only the size limit common to all purposes is applied, and the file is
+			// validated for the purpose once it is given.
+			fr := rule
+			if fr == nil {
+				fr = anyPurposeRule
+				uploadedBeforePurpose = true
+			}
+			// Reject the file before uploading it.
+			if err := fr.validateFilename(filename); err != nil {
+				abort(err.Error(), http.StatusBadRequest)
+				return
+			}
+			cr := &countingReader{r: part, limit: fr.maxBytes}
+			var r io.Reader = cr
+			if cv = fr.newContentValidator(); cv != nil {
+				// Stop uploading the file once too many errors are found.
+				r = io.TeeReader(cr, cv)
+			}
+			sums = newChecksums()
+			r = io.TeeReader(r, sums)
+			path = s.filePath(fileID)
+
+			s.log.Info("Uploading the file to the object store", "fileID", fileID)
+			etag, err = s.objectStore.Upload(req.Context(), r, path, enc)
+			if err != nil {
+				// Check the counter and the validator instead of the error as the object store client
+				// might not wrap errors.
+				if cr.exceeded() {
+					abort(fr.validateSize(cr.n).Error(), http.StatusRequestEntityTooLarge)
+					return
+				}
+				if cv != nil && !cv.Valid() {
+					abortInvalidContent()
+					return
+				}
+				abort(err.Error(), http.StatusInternalServerError)
+				return
+			}
+			bytes = cr.n
+			s.log.Info("Uploaded the file", "fileID", fileID, "bytes", bytes)
+		default:
+			if key, ok := metadataFormKey(part.FormName()); ok {
+				value, err := readFormValue(part)
+				if err != nil {
+					abort(err.Error(), http.StatusBadRequest)
+					return
+				}
+				if err := addMetadataFormPair(metadata, key, value); err != nil {
+					abort(statusMessage(err), httpStatusCode(err))
+					return
+				}
+			}
+		}
+		_ = part.Close()
 	}
-	defer func() {
-		_ = file.Close()
-	}()
 
-	s.log.Info("Uploading the file to S3")
-	fileID, err := id.GenerateID("file-", 24)
+	if purpose == "" {
+		abort("purpose is required", http.StatusBadRequest)
+		return
+	}
+	if path == "" {
+		abort("file is required", http.StatusBadRequest)
+		return
+	}
+	var expiresAfter *v1.ExpiresAfter
+	if expiresAfterAnchor != "" || expiresAfterSeconds != "" {
+		secs, err := strconv.ParseInt(expiresAfterSeconds, 10, 64)
+		if err != nil {
+			abort(fmt.Sprintf("invalid expires_after[seconds]: %q", expiresAfterSeconds), http.StatusBadRequest)
+			return
+		}
+		expiresAfter = &v1.ExpiresAfter{Anchor: expiresAfterAnchor, Seconds: secs}
+	}
+	expiresAt, err := fileExpiresAt(expiresAfter, start)
 	if err != nil {
-		httpError(w, fmt.Sprintf("generate file id: %s", err.Error()), http.StatusInternalServerError, &usage)
+		abort(err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	path := s.filePath(fileID)
-	if err := s.s3Client.Upload(req.Context(), file, path); err != nil {
-		httpError(w, err.Error(), http.StatusInternalServerError, &usage)
+	if uploadedBeforePurpose {
+		if err := rule.validateFilename(filename); err != nil {
+			abort(err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := rule.validateSize(bytes); err != nil {
+		abort(err.Error(), http.StatusRequestEntityTooLarge)
 		return
 	}
-	s.log.Info("Uploaded the file", "header(bytes)", header.Size)
+	if uploadedBeforePurpose {
+		// Read the uploaded object again as the content was not validated for the purpose while it was streamed.
+		if cv, _, err = s.scanUploadedObject(req.Context(), rule, path, bytes, enc); err != nil {
+			abort(fmt.Sprintf("read uploaded object: %s", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	var (
+		validationStatus store.ValidationStatus
+		lines            int64
+	)
+	if cv != nil {
+		cv.Flush()
+		if !cv.Valid() {
+			abortInvalidContent()
+			return
+		}
+		validationStatus = store.ValidationStatusValid
+		lines = int64(cv.Lines())
+	}
 
-	f, err := s.store.CreateFile(store.FileSpec{
+	spec := store.FileSpec{
 		FileID:         fileID,
 		TenantID:       userInfo.TenantID,
 		OrganizationID: userInfo.OrganizationID,
 		ProjectID:      userInfo.ProjectID,
 
 		Purpose:  purpose,
-		Filename: header.Filename,
-		Bytes:    header.Size,
+		Filename: filename,
+		Bytes:    bytes,
 
 		ObjectStorePath: path,
-	})
+		ETag:            etag,
+
+		ValidationStatus: validationStatus,
+		Lines:            lines,
+
+		ExpiresAt: expiresAt,
+
+		SHA256: sums.sha256Hex(),
+		MD5:    sums.md5Hex(),
+
+		EncryptionMode: enc.Mode,
+
+		Metadata: metadata,
+	}
+	var f *store.File
+	if s.enableDedup {
+		f, err = s.createDeduplicatedFile(req.Context(), spec)
+	} else {
+		f, err = s.createFileWithQuota(spec)
+	}
 	if err != nil {
-		httpError(w, err.Error(), http.StatusBadRequest, &usage)
+		abort(statusMessage(err), httpStatusCode(err))
 		return
 	}
 
 	fj := toFileJSON(f)
+	if len(metadata) > 0 {
+		fj.Metadata = metadata
+	}
 	b, err := json.Marshal(fj)
 	if err != nil {
 		httpError(w, err.Error(), http.StatusInternalServerError, &usage)
@@ -133,7 +333,78 @@ func (s *S) GetFileContent(
 	req *http.Request,
 	pathParams map[string]string,
 ) {
-	http.Error(w, "Not implemented", http.StatusInternalServerError)
+	start := time.Now()
+	status, userInfo, err := s.reqIntercepter.InterceptHTTPRequest(req)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	usage := auv1.UsageRecord{
+		UserId:       userInfo.InternalUserID,
+		Tenant:       userInfo.TenantID,
+		Organization: userInfo.OrganizationID,
+		Project:      userInfo.ProjectID,
+		ApiMethod:    "/llmariner.files.server.v1.FileService/GetFileContent",
+		StatusCode:   http.StatusOK,
+		Timestamp:    start.UnixNano(),
+	}
+	defer func() {
+		usage.LatencyMs = int32(time.Since(start).Milliseconds())
+		s.usage.AddUsage(&usage)
+	}()
+
+	fileID := pathParams["id"]
+	if fileID == "" {
+		httpError(w, "id is required", http.StatusBadRequest, &usage)
+		return
+	}
+
+	f, err := s.store.GetFile(fileID, userInfo.ProjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			httpError(w, fmt.Sprintf("file %q not found", fileID), http.StatusNotFound, &usage)
+			return
+		}
+		httpError(w, fmt.Sprintf("get file: %s", err), http.StatusInternalServerError, &usage)
+		return
+	}
+
+	if isExternalObjectPath(f.ObjectStorePath) {
+		// The control plane might not have access to the object. Only serve files that
+		// have been uploaded through file-manager.
+		httpError(w, fmt.Sprintf("content of file %q is not available as it was created from an object path", fileID), http.StatusBadRequest, &usage)
+		return
+	}
+
+	// Check the object before sending the headers so that errors are reported with the status code.
+	// This also covers empty files, whose objects are never downloaded.
+	if _, _, _, err := s.objectStore.Stat(req.Context(), f.ObjectStorePath, fileEncryption(f)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			httpError(w, fmt.Sprintf("object of file %q not found", fileID), http.StatusNotFound, &usage)
+			return
+		}
+		httpError(w, fmt.Sprintf("get file content: %s", err), http.StatusInternalServerError, &usage)
+		return
+	}
+
+	// http.ServeContent handles range requests and conditional requests. The content is
+	// read from the object store with ranged downloads so that only the requested bytes are
+	// transferred.
+	r := newObjectReader(req.Context(), s.objectStore, f.ObjectStorePath, f.Bytes, fileEncryption(f))
+	defer func() {
+		_ = r.Close()
+	}()
+
+	w.Header().Set("Content-Type", contentType(f.Filename))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": f.Filename}))
+	if f.ETag != "" {
+		w.Header().Set("ETag", f.ETag)
+	}
+
+	sw := &statusRecordingWriter{ResponseWriter: w, status: http.StatusOK}
+	http.ServeContent(sw, req, f.Filename, f.CreatedAt, r)
+	usage.StatusCode = int32(sw.status)
 }
 
 // ListFiles lists files.
@@ -166,39 +437,75 @@ func (s *S) ListFiles(
 		order = req.Order
 	}
 
-	var afterID uint
-	if req.After != "" {
-		file, err := s.store.GetFileByFileIDAndProjectID(req.After, userInfo.ProjectID)
+	sortKey := store.FileSortKeyCreatedAt
+	if req.SortBy != "" {
+		sortKey = store.FileSortKey(req.SortBy)
+		if err := validateFileSortKey(sortKey); err != nil {
+			return nil, err
+		}
+	}
+
+	filter, err := newFileFilter(userInfo.ProjectID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.After != "" && req.Cursor != "" {
+		return nil, status.Errorf(codes.InvalidArgument, "after and cursor cannot be specified together")
+	}
+	var after *store.File
+	if req.Cursor != "" {
+		c, err := decodeFileCursor(req.Cursor, sortKey, order)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cursor: %s", err)
+		}
+		after = c.file()
+	} else if req.After != "" {
+		after, err = s.store.GetFileByFileIDAndProjectID(req.After, userInfo.ProjectID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return nil, status.Errorf(codes.InvalidArgument, "invalid after: %s", err)
 			}
 			return nil, status.Errorf(codes.Internal, "get file: %s", err)
 		}
-		afterID = file.ID
 	}
 
-	var fs []*store.File
-	var hasMore bool
-	var err error
-	if p := req.Purpose; p != "" {
-		if err := validatePurpose(p); err != nil {
-			return nil, err
-		}
-		fs, hasMore, err = s.store.ListFilesByProjectIDAndPurposeWithPagination(userInfo.ProjectID, p, afterID, int(limit), order)
-	} else {
-		fs, hasMore, err = s.store.ListFilesByProjectIDWithPagination(userInfo.ProjectID, afterID, int(limit), order)
-	}
+	fs, hasMore, err := s.store.ListFiles(store.ListFilesOptions{
+		Filter:  filter,
+		SortKey: sortKey,
+		Order:   order,
+		After:   after,
+		Limit:   int(limit),
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "list files: %s", err)
 	}
 
+	var fileIDs []string
+	for _, f := range fs {
+		fileIDs = append(fileIDs, f.FileID)
+	}
+	mds, err := s.store.ListFileMetadata(fileIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list file metadata: %s", err)
+	}
+
 	var fileProtos []*v1.File
 	for _, f := range fs {
-		fileProtos = append(fileProtos, toFileProto(f))
+		fp := toFileProto(f)
+		fp.Metadata = mds[f.FileID]
+		fileProtos = append(fileProtos, fp)
 	}
 
-	totalItems, err := s.store.CountFilesByProjectID(userInfo.ProjectID)
+	var nextCursor string
+	if hasMore {
+		nextCursor, err = newFileCursor(fs[len(fs)-1], sortKey, order).encode()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "encode cursor: %s", err)
+		}
+	}
+
+	totalItems, err := s.store.CountFiles(filter)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "count files: %s", err)
 	}
@@ -208,6 +515,117 @@ func (s *S) ListFiles(
 		Data:       fileProtos,
 		HasMore:    hasMore,
 		TotalItems: int32(totalItems),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// newFileFilter validates the filters of the request and returns the filter of the store.
+func newFileFilter(projectID string, req *v1.ListFilesRequest) (store.FileFilter, error) {
+	if p := req.Purpose; p != "" {
+		if err := validatePurpose(p); err != nil {
+			return store.FileFilter{}, err
+		}
+	}
+	fileStatus := store.FileStatus(req.Status)
+	if err := validateFileStatus(fileStatus); err != nil {
+		return store.FileFilter{}, err
+	}
+
+	if req.CreatedAtStart < 0 || req.CreatedAtEnd < 0 {
+		return store.FileFilter{}, status.Errorf(codes.InvalidArgument, "created_at_start and created_at_end must be non-negative")
+	}
+	if req.CreatedAtStart > 0 && req.CreatedAtEnd > 0 && req.CreatedAtStart >= req.CreatedAtEnd {
+		return store.FileFilter{}, status.Errorf(codes.InvalidArgument, "created_at_start must be less than created_at_end")
+	}
+	if req.MinBytes < 0 || req.MaxBytes < 0 {
+		return store.FileFilter{}, status.Errorf(codes.InvalidArgument, "min_bytes and max_bytes must be non-negative")
+	}
+	if req.MaxBytes > 0 && req.MinBytes > req.MaxBytes {
+		return store.FileFilter{}, status.Errorf(codes.InvalidArgument, "min_bytes must not be greater than max_bytes")
+	}
+
+	f := store.FileFilter{
+		ProjectID:             projectID,
+		Purpose:               req.Purpose,
+		Status:                fileStatus,
+		FilenamePrefix:        req.FilenamePrefix,
+		FilenameContains:      req.FilenameContains,
+		MinBytes:              req.MinBytes,
+		MaxBytes:              req.MaxBytes,
+		ObjectStorePathPrefix: req.ObjectStorePathPrefix,
+	}
+	if t := req.CreatedAtStart; t > 0 {
+		f.CreatedAtStart = time.Unix(t, 0)
+	}
+	if t := req.CreatedAtEnd; t > 0 {
+		f.CreatedAtEnd = time.Unix(t, 0)
+	}
+	reqs, err := parseMetadataSelector(req.MetadataSelector)
+	if err != nil {
+		return store.FileFilter{}, err
+	}
+	f.Metadata = reqs
+	return f, nil
+}
+
+// GetFileStats returns the number and the total size of files in the project broken down by purpose and status.
+func (s *S) GetFileStats(
+	ctx context.Context,
+	req *v1.GetFileStatsRequest,
+) (*v1.FileStats, error) {
+	userInfo, ok := auth.ExtractUserInfoFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to extract user info from context")
+	}
+
+	stats, err := s.store.ListFileStatsByProjectID(userInfo.ProjectID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list file stats: %s", err)
+	}
+
+	resp := &v1.FileStats{}
+	byPurpose := map[string]*v1.FileCount{}
+	byStatus := map[string]*v1.FileCount{}
+	for _, st := range stats {
+		resp.Files += st.Files
+		resp.Bytes += st.Bytes
+		addFileCount(byPurpose, st.Purpose, st)
+		addFileCount(byStatus, string(st.Status), st)
+	}
+	resp.ByPurpose = sortedFileCounts(byPurpose)
+	resp.ByStatus = sortedFileCounts(byStatus)
+	return resp, nil
+}
+
+func addFileCount(m map[string]*v1.FileCount, key string, st *store.FileStats) {
+	c, ok := m[key]
+	if !ok {
+		c = &v1.FileCount{Key: key}
+		m[key] = c
+	}
+	c.Files += st.Files
+	c.Bytes += st.Bytes
+}
+
+func sortedFileCounts(m map[string]*v1.FileCount) []*v1.FileCount {
+	var cs []*v1.FileCount
+	for _, c := range m {
+		cs = append(cs, c)
+	}
+	sort.Slice(cs, func(i, j int) bool { return cs[i].Key < cs[j].Key })
+	return cs
+}
+
+// GetFileCapabilities returns the features that are enabled in the server.
+func (s *S) GetFileCapabilities(
+	ctx context.Context,
+	req *v1.GetFileCapabilitiesRequest,
+) (*v1.FileCapabilities, error) {
+	if _, ok := auth.ExtractUserInfoFromContext(ctx); !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to extract user info from context")
+	}
+	return &v1.FileCapabilities{
+		FileUploadEnabled: s.enableFileUpload,
 	}, nil
 }
 
@@ -232,7 +650,117 @@ func (s *S) GetFile(
 		}
 		return nil, status.Errorf(codes.Internal, "get file: %s", err)
 	}
-	return toFileProto(f), nil
+	md, err := s.store.GetFileMetadata(f.FileID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get file metadata: %s", err)
+	}
+	fp := toFileProto(f)
+	fp.Metadata = md
+	return fp, nil
+}
+
+// UpdateFile updates the filename, the purpose, and the metadata of a file.
+func (s *S) UpdateFile(
+	ctx context.Context,
+	req *v1.UpdateFileRequest,
+) (*v1.File, error) {
+	userInfo, ok := auth.ExtractUserInfoFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to extract user info from context")
+	}
+
+	if req.File == nil || req.File.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "file.id is required")
+	}
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask is required")
+	}
+
+	f, err := s.store.GetFile(req.File.Id, userInfo.ProjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "file %q not found", req.File.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "get file: %s", err)
+	}
+	if v := req.File.Version; v != 0 && v != f.Version {
+		return nil, status.Errorf(codes.Aborted, "file %q has been modified. the current version is %d", f.FileID, f.Version)
+	}
+
+	var u store.FileUpdate
+	filename, purpose := f.Filename, f.Purpose
+	for _, path := range req.UpdateMask.Paths {
+		switch path {
+		case "filename":
+			if req.File.Filename == "" {
+				return nil, status.Error(codes.InvalidArgument, "filename must not be empty")
+			}
+			filename = req.File.Filename
+			u.Filename = &filename
+		case "purpose":
+			purpose = req.File.Purpose
+			u.Purpose = &purpose
+		case "metadata":
+			if err := validateMetadata(req.File.Metadata); err != nil {
+				return nil, err
+			}
+			u.Metadata = req.File.Metadata
+			if u.Metadata == nil {
+				u.Metadata = map[string]string{}
+			}
+		case "id", "version":
+			// id identifies the file and version is the precondition of the update.
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported field in update_mask: %q", path)
+		}
+	}
+
+	if u.Purpose != nil && purpose == f.Purpose {
+		// Keep the result of the validation of the content.
+		u.Purpose = nil
+	}
+	if u.Filename != nil || u.Purpose != nil {
+		var rule *purposeRule
+		if u.Purpose != nil {
+			// Users cannot change the purpose to one of the purposes of files generated by jobs.
+			rule, err = validateUploadPurpose(purpose)
+		} else {
+			rule, err = lookupPurposeRule(purpose)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if u.Purpose != nil {
+			if err := rule.validateSize(f.Bytes); err != nil {
+				return nil, err
+			}
+			cv, err := s.validateFileContent(ctx, rule, f)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "validate content: %s", err)
+			}
+			if cv != nil {
+				if !cv.Valid() {
+					return nil, contentValidationError(purpose, cv)
+				}
+				u.ValidationStatus = store.ValidationStatusValid
+				u.Lines = int64(cv.Lines())
+			}
+		}
+		// Only the extensions of uploaded files are validated as with their creation.
+		if !isExternalObjectPath(f.ObjectStorePath) {
+			if err := rule.validateFilename(filename); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.store.UpdateFileWithVersion(f.FileID, f.Version, u); err != nil {
+		if errors.Is(err, store.ErrConcurrentUpdate) {
+			return nil, status.Errorf(codes.Aborted, "file %q has been modified concurrently", f.FileID)
+		}
+		return nil, status.Errorf(codes.Internal, "update file: %s", err)
+	}
+	return s.GetFile(ctx, &v1.GetFileRequest{Id: f.FileID})
 }
 
 // DeleteFile deletes a file.
@@ -249,7 +777,15 @@ func (s *S) DeleteFile(
 		return nil, status.Error(codes.InvalidArgument, "id is required")
 	}
 
-	if err := s.store.DeleteFile(req.Id, userInfo.ProjectID); err != nil {
+	f, err := s.store.GetFile(req.Id, userInfo.ProjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "file %q not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "get file: %s", err)
+	}
+
+	if err := deleteFile(s.store, f); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, status.Errorf(codes.NotFound, "file %q not found", req.Id)
 		}
@@ -277,21 +813,32 @@ func (s *S) CreateFileFromObjectPath(
 		return nil, status.Error(codes.InvalidArgument, "object_path is required")
 	}
 
-	if !strings.HasPrefix(req.ObjectPath, "s3://") {
+	if !isExternalObjectPath(req.ObjectPath) {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid object path: %q. must start with 's3://'", req.ObjectPath)
 	}
 
-	if err := validatePurpose(req.Purpose); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	rule, err := lookupPurposeRule(req.Purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, key, ok := parseExternalObjectPath(req.ObjectPath)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid object path: %q. must be in the form of 's3://<bucket>/<key>'", req.ObjectPath)
 	}
 
-	fileID, err := id.GenerateID("file-", 24)
+	// The extension of the filename is not validated as the keys of registered objects are chosen
+	// by the systems that write them (e.g., keys without ".jsonl").
+	filename := filepath.Base(req.ObjectPath)
+	expiresAt, err := fileExpiresAt(req.ExpiresAfter, time.Now())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "generate file id: %s", err)
+		return nil, err
+	}
+	if err := validateMetadata(req.Metadata); err != nil {
+		return nil, err
 	}
 
-	f, err := s.store.CreateFile(store.FileSpec{
-		FileID:         fileID,
+	spec := store.FileSpec{
 		TenantID:       userInfo.TenantID,
 		OrganizationID: userInfo.OrganizationID,
 		ProjectID:      userInfo.ProjectID,
@@ -299,18 +846,62 @@ func (s *S) CreateFileFromObjectPath(
 		Purpose: req.Purpose,
 
 		// Use the basename as a filename.
-		Filename: filepath.Base(req.ObjectPath),
-
-		// Set to 0 as we don't know the size.
-		Bytes: 0,
+		Filename: filename,
 
 		ObjectStorePath: req.ObjectPath,
-	})
+
+		ExpiresAt: expiresAt,
+
+		Metadata: req.Metadata,
+	}
+
+	// Look up the object to record its attributes and reject missing objects. If the control plane cannot
+	// access the object (e.g., no credentials for the bucket), the object is verified later by a worker cluster.
+	sctx, cancel := context.WithTimeout(ctx, objectVerificationTimeout)
+	defer cancel()
+	size, etag, lastModified, err := s.objectStore.StatBucketObject(sctx, bucket, key)
+	switch {
+	case err == nil:
+		if err := rule.validateSize(size); err != nil {
+			return nil, err
+		}
+		if rule.newLineValidator != nil && rule.validateRegisteredObjects {
+			cv, err := s.validateRegisteredObject(ctx, rule, bucket, key, size)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "validate object: %s", err)
+			}
+			if !cv.Valid() {
+				return nil, contentValidationError(req.Purpose, cv)
+			}
+			spec.ValidationStatus = store.ValidationStatusValid
+			spec.Lines = int64(cv.Lines())
+		}
+		spec.Bytes = size
+		spec.ETag = etag
+		spec.ObjectLastModifiedAt = lastModified
+		spec.ObjectVerificationStatus = store.ObjectVerificationStatusVerified
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, status.Errorf(codes.NotFound, "object %q not found", req.ObjectPath)
+	default:
+		s.log.V(1).Info("Failed to verify the object. Deferring the verification to worker clusters", "path", req.ObjectPath, "error", err)
+		spec.ObjectVerificationStatus = store.ObjectVerificationStatusPending
+		spec.Status = store.FileStatusUploaded
+	}
+
+	fileID, err := id.GenerateID("file-", 24)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "create file: %s", err)
+		return nil, status.Errorf(codes.Internal, "generate file id: %s", err)
+	}
+	spec.FileID = fileID
+
+	f, err := s.createFileWithQuota(spec)
+	if err != nil {
+		return nil, err
 	}
 
-	return toFileProto(f), nil
+	fp := toFileProto(f)
+	fp.Metadata = req.Metadata
+	return fp, nil
 }
 
 // GetFilePath gets a file path.
@@ -335,11 +926,92 @@ func (s *WS) GetFilePath(
 		return nil, status.Errorf(codes.Internal, "get file: %s", err)
 	}
 	return &v1.GetFilePathResponse{
-		Path:     f.ObjectStorePath,
-		Filename: f.Filename,
+		Path:           f.ObjectStorePath,
+		Filename:       f.Filename,
+		Sha256:         f.SHA256,
+		Md5:            f.MD5,
+		EncryptionMode: f.EncryptionMode,
 	}, nil
 }
 
+// ListFilesPendingObjectVerification lists files whose objects are pending verification.
+func (s *WS) ListFilesPendingObjectVerification(
+	ctx context.Context,
+	req *v1.ListFilesPendingObjectVerificationRequest,
+) (*v1.ListFilesPendingObjectVerificationResponse, error) {
+	clusterInfo, err := s.extractClusterInfoFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be non-negative")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	fs, err := s.store.ListFilesPendingObjectVerification(clusterInfo.TenantID, int(limit))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list files: %s", err)
+	}
+	var fileProtos []*v1.File
+	for _, f := range fs {
+		fileProtos = append(fileProtos, toFileProto(f))
+	}
+	return &v1.ListFilesPendingObjectVerificationResponse{
+		Files: fileProtos,
+	}, nil
+}
+
+// UpdateFileObjectVerification updates the result of the verification of an object.
+func (s *WS) UpdateFileObjectVerification(
+	ctx context.Context,
+	req *v1.UpdateFileObjectVerificationRequest,
+) (*v1.UpdateFileObjectVerificationResponse, error) {
+	clusterInfo, err := s.extractClusterInfoFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	var (
+		st            = store.ObjectVerificationStatusNotFound
+		bytes         int64
+		etag          string
+		lastModified  time.Time
+		fileStatus    = store.FileStatusError
+		statusDetails = "object not found"
+	)
+	if req.Exists {
+		if req.Bytes < 0 {
+			return nil, status.Error(codes.InvalidArgument, "bytes must be non-negative")
+		}
+		st = store.ObjectVerificationStatusVerified
+		fileStatus = store.FileStatusProcessed
+		statusDetails = ""
+		bytes = req.Bytes
+		etag = req.Etag
+		if req.LastModifiedAt > 0 {
+			lastModified = time.Unix(req.LastModifiedAt, 0)
+		}
+	}
+	if err := s.store.UpdateFileObjectVerification(req.Id, clusterInfo.TenantID, st, bytes, etag, lastModified, fileStatus, statusDetails); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "file %q pending verification not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "update file: %s", err)
+	}
+	return &v1.UpdateFileObjectVerificationResponse{}, nil
+}
+
 // GetFilePath gets a file path.
 func (s *IS) GetFilePath(
 	ctx context.Context,
@@ -357,22 +1029,118 @@ func (s *IS) GetFilePath(
 		return nil, status.Errorf(codes.Internal, "get file: %s", err)
 	}
 	return &v1.GetFilePathResponse{
-		Path:     f.ObjectStorePath,
-		Filename: f.Filename,
+		Path:           f.ObjectStorePath,
+		Filename:       f.Filename,
+		Sha256:         f.SHA256,
+		Md5:            f.MD5,
+		EncryptionMode: f.EncryptionMode,
 	}, nil
 }
 
+// deleteFile deletes a file and enqueues the deletion of its object in the same transaction so that
+// the object is deleted by the object deleter even when the object store is unavailable now.
+// Objects registered with CreateFileFromObjectPath are owned by users and never deleted. Objects
+// shared by deduplicated files are deleted when the last file is deleted.
+func deleteFile(st *store.S, f *store.File) error {
+	return st.Transaction(func(tx *gorm.DB) error {
+		if err := store.DeleteFileInTransaction(tx, f.FileID, f.ProjectID); err != nil {
+			return err
+		}
+		if isExternalObjectPath(f.ObjectStorePath) {
+			return nil
+		}
+		// Keep the object shared with other files until the last reference is released.
+		if unreferenced, err := store.ReleaseBlobInTransaction(tx, f.ObjectStorePath); err == nil {
+			if !unreferenced {
+				return nil
+			}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if _, err := store.CreateObjectDeletionInTransaction(tx, f.ObjectStorePath, time.Now()); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// fileExpiresAt returns the expiration time of a file created at the given time. It returns the zero time
+// if the expiration policy is not set.
+func fileExpiresAt(ea *v1.ExpiresAfter, createdAt time.Time) (time.Time, error) {
+	if ea == nil {
+		return time.Time{}, nil
+	}
+	if ea.Anchor != expiresAfterAnchorCreatedAt {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid expires_after anchor: %q. must be %q", ea.Anchor, expiresAfterAnchorCreatedAt)
+	}
+	d := time.Duration(ea.Seconds) * time.Second
+	if d < minExpiresAfter || d > maxExpiresAfter {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "expires_after seconds must be between %d and %d", int64(minExpiresAfter.Seconds()), int64(maxExpiresAfter.Seconds()))
+	}
+	return createdAt.Add(d), nil
+}
+
+// readFormValue reads a non-file form value in a multipart request.
+func readFormValue(part *multipart.Part) (string, error) {
+	b, err := io.ReadAll(io.LimitReader(part, maxFormValueBytes+1))
+	if err != nil {
+		return "", err
+	}
+	if len(b) > maxFormValueBytes {
+		return "", fmt.Errorf("%s is too long", part.FormName())
+	}
+	return string(b), nil
+}
+
+// enqueueObjectDeletion enqueues the deletion of an object that is not referenced by any file.
+// The object is deleted by the object deleter or the garbage collector if this fails.
+func (s *S) enqueueObjectDeletion(path string) {
+	if _, err := s.store.CreateObjectDeletion(path, time.Now()); err != nil {
+		s.log.Error(err, "Failed to enqueue the object deletion", "path", path)
+	}
+}
+
 func (s *S) filePath(key string) string {
 	return fmt.Sprintf("%s/%s", s.pathPrefix, key)
 }
 
-func validatePurpose(p string) error {
-	switch p {
-	case purposeFineTune, purposeAssistants:
-		return nil
-	default:
-		return status.Errorf(codes.InvalidArgument, "invalid purpose: %q. must be either %q or %q", p, purposeFineTune, purposeAssistants)
+// newObjectEncryption returns the server-side encryption of new objects of the tenant.
+func (s *S) newObjectEncryption(tenantID string) objectstore.Encryption {
+	return objectstore.Encryption{
+		TenantID: tenantID,
+		Mode:     s.objectStore.EncryptionMode(tenantID),
+	}
+}
+
+// fileEncryption returns the server-side encryption of the object of the file.
+func fileEncryption(f *store.File) objectstore.Encryption {
+	return objectstore.Encryption{
+		TenantID: f.TenantID,
+		Mode:     f.EncryptionMode,
+	}
+}
+
+// isExternalObjectPath returns true if the path points to an object that has been registered
+// with CreateFileFromObjectPath instead of being uploaded by file-manager.
+func isExternalObjectPath(path string) bool {
+	return strings.HasPrefix(path, "s3://")
+}
+
+// parseExternalObjectPath returns the bucket and the key of an external object path.
+func parseExternalObjectPath(path string) (string, string, bool) {
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(path, "s3://"), "/")
+	if !ok || bucket == "" || key == "" {
+		return "", "", false
 	}
+	return bucket, key, true
+}
+
+// contentType returns the content type of the file based on its extension.
+func contentType(filename string) string {
+	if t := mime.TypeByExtension(filepath.Ext(filename)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
 }
 
 func toFileProto(f *store.File) *v1.File {
@@ -384,17 +1152,41 @@ func toFileProto(f *store.File) *v1.File {
 		Object:    "file",
 		Purpose:   f.Purpose,
 
-		ObjectStorePath: f.ObjectStorePath,
+		ObjectStorePath:          f.ObjectStorePath,
+		ObjectVerificationStatus: string(f.ObjectVerificationStatus),
+
+		Status:        string(f.Status),
+		StatusDetails: f.StatusDetails,
+
+		ExpiresAt: expiresAtUnix(f.ExpiresAt),
+
+		Sha256: f.SHA256,
+		Md5:    f.MD5,
+
+		Version: f.Version,
 	}
 }
 
+// expiresAtUnix returns the Unix timestamp of the expiration time. It returns 0 if the file does not expire.
+func expiresAtUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UTC().Unix()
+}
+
 type fileJSON struct {
-	ID        string `json:"id"`
-	Bytes     int64  `json:"bytes"`
-	CreatedAt int64  `json:"created_at"`
-	Filename  string `json:"filename"`
-	Object    string `json:"object"`
-	Purpose   string `json:"purpose"`
+	ID            string `json:"id"`
+	Bytes         int64  `json:"bytes"`
+	CreatedAt     int64  `json:"created_at"`
+	Filename      string `json:"filename"`
+	Object        string `json:"object"`
+	Purpose       string `json:"purpose"`
+	Status        string `json:"status"`
+	StatusDetails string `json:"status_details,omitempty"`
+	ExpiresAt     int64  `json:"expires_at,omitempty"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 func toFileJSON(f *store.File) *fileJSON {
@@ -405,6 +1197,55 @@ func toFileJSON(f *store.File) *fileJSON {
 		Filename:  f.Filename,
 		Object:    "file",
 		Purpose:   f.Purpose,
+
+		Status:        string(f.Status),
+		StatusDetails: f.StatusDetails,
+		ExpiresAt:     expiresAtUnix(f.ExpiresAt),
+	}
+}
+
+// validateFileStatus validates the status used to filter files. An empty status is allowed.
+func validateFileStatus(st store.FileStatus) error {
+	switch st {
+	case "", store.FileStatusUploaded, store.FileStatusProcessed, store.FileStatusError:
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "invalid status: %q. must be one of %s, %s, %s",
+		st, store.FileStatusUploaded, store.FileStatusProcessed, store.FileStatusError)
+}
+
+// validateFileSortKey validates the key used to sort files.
+func validateFileSortKey(k store.FileSortKey) error {
+	switch k {
+	case store.FileSortKeyCreatedAt, store.FileSortKeyFilename, store.FileSortKeyBytes:
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "invalid sort_by: %q. must be one of %s, %s, %s",
+		k, store.FileSortKeyCreatedAt, store.FileSortKeyFilename, store.FileSortKeyBytes)
+}
+
+// statusMessage returns the message of a gRPC status error.
+func statusMessage(err error) string {
+	return status.Convert(err).Message()
+}
+
+// httpStatusCode returns the HTTP status code of a gRPC status error.
+func httpStatusCode(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.ResourceExhausted:
+		return http.StatusRequestEntityTooLarge
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
 	}
 }
 
@@ -412,3 +1253,28 @@ func httpError(w http.ResponseWriter, error string, code int, usage *auv1.UsageR
 	usage.StatusCode = int32(code)
 	http.Error(w, error, code)
 }
+
+// errFileTooLarge is returned by countingReader when the number of read bytes exceeds the limit.
+var errFileTooLarge = errors.New("file too large")
+
+// countingReader is an io.Reader that counts the number of bytes read.
+type countingReader struct {
+	r io.Reader
+	n int64
+	// limit is the maximum number of bytes to read. There is no limit if 0.
+	limit int64
+}
+
+// Read implements io.Reader.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if c.exceeded() {
+		return n, errFileTooLarge
+	}
+	return n, err
+}
+
+func (c *countingReader) exceeded() bool {
+	return c.limit > 0 && c.n > c.limit
+}
@@ -3,26 +3,42 @@ package server
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr/testr"
 	"github.com/llmariner/api-usage/pkg/sender"
 	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/config"
 	"github.com/llmariner/file-manager/server/internal/store"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	// fineTuneLine is a valid line of a fine-tuning dataset.
+	fineTuneLine = `{"messages": [{"role": "user", "content": "Hi"}, {"role": "assistant", "content": "Hello"}]}`
+	// batchLine is a valid line of a batch input file.
+	batchLine = `{"custom_id": "request-1", "method": "POST", "url": "/v1/chat/completions", "body": {"model": "m", "messages": [{"role": "user", "content": "Hi"}]}}`
 )
 
 func TestFiles(t *testing.T) {
 	st, tearDown := store.NewTest(t)
 	defer tearDown()
 
-	srv := New(st, &NoopS3Client{}, &sender.NoopUsageSetter{}, "pathPrefix", true, testr.New(t))
+	srv := New(st, newMemoryObjectStore(), &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
 	ctx := fakeAuthInto(context.Background())
 
 	const (
@@ -68,11 +84,351 @@ func TestFiles(t *testing.T) {
 	assert.Len(t, listResp.Data, 0)
 }
 
+func TestListFilesTotalItems(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	srv := New(st, newMemoryObjectStore(), &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	createTestFiles(t, st)
+
+	tcs := []struct {
+		name string
+		req  *v1.ListFilesRequest
+		want int32
+	}{
+		{
+			name: "no filter",
+			req:  &v1.ListFilesRequest{},
+			want: 4,
+		},
+		{
+			name: "purpose",
+			req:  &v1.ListFilesRequest{Purpose: purposeFineTune},
+			want: 3,
+		},
+		{
+			name: "purpose and status",
+			req:  &v1.ListFilesRequest{Purpose: purposeFineTune, Status: string(store.FileStatusError)},
+			want: 1,
+		},
+		{
+			name: "pagination",
+			req:  &v1.ListFilesRequest{Purpose: purposeFineTune, Limit: 1},
+			want: 3,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := srv.ListFiles(ctx, tc.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, resp.TotalItems)
+		})
+	}
+}
+
+func TestListFilesWithFilters(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	srv := New(st, newMemoryObjectStore(), &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	createTestFiles(t, st)
+
+	resp, err := srv.ListFiles(ctx, &v1.ListFilesRequest{
+		FilenamePrefix: "file",
+		MinBytes:       20,
+		SortBy:         string(store.FileSortKeyBytes),
+		Order:          "asc",
+		Limit:          2,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 2)
+	assert.Equal(t, "f1", resp.Data[0].Id)
+	assert.Equal(t, "f2", resp.Data[1].Id)
+	assert.True(t, resp.HasMore)
+	assert.Equal(t, int32(3), resp.TotalItems)
+
+	resp, err = srv.ListFiles(ctx, &v1.ListFilesRequest{
+		FilenamePrefix: "file",
+		MinBytes:       20,
+		SortBy:         string(store.FileSortKeyBytes),
+		Order:          "asc",
+		Limit:          2,
+		After:          "f2",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, "f3", resp.Data[0].Id)
+	assert.False(t, resp.HasMore)
+
+	resp, err = srv.ListFiles(ctx, &v1.ListFilesRequest{
+		CreatedAtEnd: time.Now().Add(-time.Hour).Unix(),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Data)
+
+	for _, req := range []*v1.ListFilesRequest{
+		{SortBy: "purpose"},
+		{MinBytes: 20, MaxBytes: 10},
+		{MinBytes: -1},
+		{CreatedAtStart: 20, CreatedAtEnd: 10},
+	} {
+		_, err := srv.ListFiles(ctx, req)
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestListFilesWithCursor(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	srv := New(st, newMemoryObjectStore(), &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	createTestFiles(t, st)
+
+	tcs := []struct {
+		sortBy string
+		order  string
+		want   []string
+	}{
+		{
+			want: []string{"f3", "f2", "f1", "f0"},
+		},
+		{
+			order: "asc",
+			want:  []string{"f0", "f1", "f2", "f3"},
+		},
+		{
+			sortBy: string(store.FileSortKeyFilename),
+			order:  "asc",
+			want:   []string{"f0", "f1", "f2", "f3"},
+		},
+		{
+			sortBy: string(store.FileSortKeyBytes),
+			want:   []string{"f3", "f2", "f1", "f0"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.sortBy+" "+tc.order, func(t *testing.T) {
+			var got []string
+			var cursor string
+			for {
+				resp, err := srv.ListFiles(ctx, &v1.ListFilesRequest{
+					SortBy: tc.sortBy,
+					Order:  tc.order,
+					Limit:  3,
+					Cursor: cursor,
+				})
+				assert.NoError(t, err)
+				for _, f := range resp.Data {
+					got = append(got, f.Id)
+				}
+				if !resp.HasMore {
+					assert.Empty(t, resp.NextCursor)
+					break
+				}
+				assert.NotEmpty(t, resp.NextCursor)
+				cursor = resp.NextCursor
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	resp, err := srv.ListFiles(ctx, &v1.ListFilesRequest{
+		SortBy: string(store.FileSortKeyBytes),
+		Limit:  2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "f2", resp.Data[1].Id)
+
+	// The cursor remains valid after the last file of the page is deleted.
+	err = st.DeleteFile("f2", defaultProjectID)
+	assert.NoError(t, err)
+	_, err = srv.ListFiles(ctx, &v1.ListFilesRequest{
+		SortBy: string(store.FileSortKeyBytes),
+		After:  "f2",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err = srv.ListFiles(ctx, &v1.ListFilesRequest{
+		SortBy: string(store.FileSortKeyBytes),
+		Cursor: resp.NextCursor,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 2)
+	assert.Equal(t, "f1", resp.Data[0].Id)
+	assert.Equal(t, "f0", resp.Data[1].Id)
+
+	cursor := mustEncodeFileCursor(t, &fileCursor{SortKey: store.FileSortKeyCreatedAt, Order: "desc", ID: 1})
+	for _, req := range []*v1.ListFilesRequest{
+		{Cursor: "invalid"},
+		{Cursor: cursor, After: "f0"},
+		// The cursor was created for another sort key.
+		{Cursor: cursor, SortBy: string(store.FileSortKeyBytes)},
+		// The cursor was created for another order.
+		{Cursor: cursor, Order: "asc"},
+	} {
+		_, err := srv.ListFiles(ctx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func mustEncodeFileCursor(t *testing.T, c *fileCursor) string {
+	s, err := c.encode()
+	assert.NoError(t, err)
+	return s
+}
+
+func TestUpdateFile(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	for i, content := range []string{fineTuneLine + "\n", "hello"} {
+		path := fmt.Sprintf("pathPrefix/f%d", i)
+		objectStore.objs[path] = []byte(content)
+		_, err := st.CreateFile(store.FileSpec{
+			FileID:          fmt.Sprintf("f%d", i),
+			TenantID:        defaultTenantID,
+			ProjectID:       defaultProjectID,
+			Filename:        "test.txt",
+			Purpose:         purposeAssistants,
+			Bytes:           int64(len(content)),
+			ObjectStorePath: path,
+		})
+		assert.NoError(t, err)
+	}
+
+	update := func(f *v1.File, paths ...string) (*v1.File, error) {
+		return srv.UpdateFile(ctx, &v1.UpdateFileRequest{
+			File:       f,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+		})
+	}
+
+	got, err := update(&v1.File{Id: "f0", Filename: "renamed.txt", Version: 1}, "filename")
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed.txt", got.Filename)
+	assert.Equal(t, purposeAssistants, got.Purpose)
+	assert.Equal(t, int64(2), got.Version)
+
+	// The version is stale.
+	_, err = update(&v1.File{Id: "f0", Filename: "test.txt", Version: 1}, "filename")
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	// The extension is not allowed for the purpose.
+	_, err = update(&v1.File{Id: "f0", Purpose: purposeFineTune}, "purpose")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	got, err = update(&v1.File{Id: "f0", Filename: "renamed.jsonl", Purpose: purposeFineTune}, "filename", "purpose")
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed.jsonl", got.Filename)
+	assert.Equal(t, purposeFineTune, got.Purpose)
+	assert.Equal(t, int64(3), got.Version)
+	f, err := st.GetFileByFileID("f0")
+	assert.NoError(t, err)
+	assert.Equal(t, store.ValidationStatusValid, f.ValidationStatus)
+	assert.Equal(t, int64(1), f.Lines)
+
+	// The content is not valid for the purpose.
+	_, err = update(&v1.File{Id: "f1", Filename: "test.jsonl", Purpose: purposeFineTune}, "filename", "purpose")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	f, err = st.GetFileByFileID("f1")
+	assert.NoError(t, err)
+	assert.Equal(t, "test.txt", f.Filename)
+	assert.Equal(t, purposeAssistants, f.Purpose)
+	assert.Equal(t, int64(1), f.Version)
+
+	for _, req := range []*v1.UpdateFileRequest{
+		{File: &v1.File{Id: "f1", Filename: "test.jsonl"}},
+		{File: &v1.File{Id: "f1", Bytes: 10}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"bytes"}}},
+		{File: &v1.File{Id: "f1"}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"filename"}}},
+		{File: &v1.File{Id: "f1", Purpose: purposeFineTuneResults}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"purpose"}}},
+		{File: &v1.File{Id: "f1", Purpose: "invalid"}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"purpose"}}},
+	} {
+		_, err := srv.UpdateFile(ctx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	_, err = update(&v1.File{Id: "missing", Filename: "test.txt"}, "filename")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetFileStats(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	srv := New(st, newMemoryObjectStore(), &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	resp, err := srv.GetFileStats(ctx, &v1.GetFileStatsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), resp.Files)
+	assert.Empty(t, resp.ByPurpose)
+
+	createTestFiles(t, st)
+
+	resp, err = srv.GetFileStats(ctx, &v1.GetFileStatsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), resp.Files)
+	assert.Equal(t, int64(100), resp.Bytes)
+	assert.Len(t, resp.ByPurpose, 2)
+	assert.Equal(t, purposeAssistants, resp.ByPurpose[0].Key)
+	assert.Equal(t, int64(1), resp.ByPurpose[0].Files)
+	assert.Equal(t, int64(40), resp.ByPurpose[0].Bytes)
+	assert.Equal(t, purposeFineTune, resp.ByPurpose[1].Key)
+	assert.Equal(t, int64(3), resp.ByPurpose[1].Files)
+	assert.Equal(t, int64(60), resp.ByPurpose[1].Bytes)
+	assert.Len(t, resp.ByStatus, 2)
+	assert.Equal(t, string(store.FileStatusError), resp.ByStatus[0].Key)
+	assert.Equal(t, int64(1), resp.ByStatus[0].Files)
+	assert.Equal(t, int64(30), resp.ByStatus[0].Bytes)
+	assert.Equal(t, string(store.FileStatusProcessed), resp.ByStatus[1].Key)
+	assert.Equal(t, int64(3), resp.ByStatus[1].Files)
+	assert.Equal(t, int64(70), resp.ByStatus[1].Bytes)
+}
+
+// createTestFiles creates files with different purposes and statuses in the default project and
+// a file in another project.
+func createTestFiles(t *testing.T, st *store.S) {
+	for i, spec := range []struct {
+		projectID string
+		purpose   string
+		status    store.FileStatus
+		bytes     int64
+	}{
+		{projectID: defaultProjectID, purpose: purposeFineTune, status: store.FileStatusProcessed, bytes: 10},
+		{projectID: defaultProjectID, purpose: purposeFineTune, status: store.FileStatusProcessed, bytes: 20},
+		{projectID: defaultProjectID, purpose: purposeFineTune, status: store.FileStatusError, bytes: 30},
+		{projectID: defaultProjectID, purpose: purposeAssistants, status: store.FileStatusProcessed, bytes: 40},
+		{projectID: "other-project", purpose: purposeFineTune, status: store.FileStatusProcessed, bytes: 50},
+	} {
+		_, err := st.CreateFile(store.FileSpec{
+			FileID:    fmt.Sprintf("f%d", i),
+			TenantID:  defaultTenantID,
+			ProjectID: spec.projectID,
+			Filename:  fmt.Sprintf("file%d.jsonl", i),
+			Purpose:   spec.purpose,
+			Status:    spec.status,
+			Bytes:     spec.bytes,
+		})
+		assert.NoError(t, err)
+	}
+}
+
 func TestCreateFile(t *testing.T) {
 	st, tearDown := store.NewTest(t)
 	defer tearDown()
 
-	srv := New(st, &NoopS3Client{}, &sender.NoopUsageSetter{}, "pathPrefix", true, testr.New(t))
+	srv := New(st, newMemoryObjectStore(), &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		srv.CreateFile(w, r, nil)
@@ -83,7 +439,7 @@ func TestCreateFile(t *testing.T) {
 
 	fw, err := w.CreateFormFile("file", "test-file.jsonl")
 	assert.NoError(t, err)
-	_, err = fw.Write([]byte("hello"))
+	_, err = fw.Write([]byte(fineTuneLine))
 	assert.NoError(t, err)
 
 	fw, err = w.CreateFormField("purpose")
@@ -107,7 +463,13 @@ func TestCreateFile(t *testing.T) {
 	assert.True(t, fj.ID != "")
 	assert.Equal(t, purposeFineTune, fj.Purpose)
 	assert.Equal(t, "test-file.jsonl", fj.Filename)
-	assert.Equal(t, int64(5), fj.Bytes)
+	assert.Equal(t, int64(len(fineTuneLine)), fj.Bytes)
+	assert.Equal(t, string(store.FileStatusProcessed), fj.Status)
+
+	f, err := st.GetFile(fj.ID, defaultProjectID)
+	assert.NoError(t, err)
+	assert.Equal(t, store.ValidationStatusValid, f.ValidationStatus)
+	assert.Equal(t, int64(1), f.Lines)
 
 	resp, err := srv.GetFile(fakeAuthInto(context.Background()), &v1.GetFileRequest{
 		Id: fj.ID,
@@ -116,6 +478,509 @@ func TestCreateFile(t *testing.T) {
 	assert.Equal(t, fj.ID, resp.Id)
 }
 
+func TestCreateFileMultipartStream(t *testing.T) {
+	type field struct {
+		name     string
+		filename string
+		value    string
+	}
+	tcs := []struct {
+		name          string
+		fields        []field
+		wantCode      int
+		wantBody      string
+		wantBytes     int64
+		wantLines     int64
+		wantDeletions int
+	}{
+		{
+			name: "purpose before file",
+			fields: []field{
+				{name: "purpose", value: purposeFineTune},
+				{name: "file", filename: "test.jsonl", value: fineTuneLine + "\n" + fineTuneLine},
+			},
+			wantCode:  http.StatusCreated,
+			wantBytes: int64(2*len(fineTuneLine) + 1),
+			wantLines: 2,
+		},
+		{
+			name: "purpose after file",
+			fields: []field{
+				{name: "file", filename: "test.jsonl", value: fineTuneLine + "\n" + fineTuneLine + "\n"},
+				{name: "purpose", value: purposeFineTune},
+			},
+			wantCode:  http.StatusCreated,
+			wantBytes: int64(2*len(fineTuneLine) + 2),
+			wantLines: 2,
+		},
+		{
+			name: "unknown fields are ignored",
+			fields: []field{
+				{name: "unknown", value: "value"},
+				{name: "file", filename: "test.jsonl", value: fineTuneLine},
+				{name: "purpose", value: purposeFineTune},
+			},
+			wantCode:  http.StatusCreated,
+			wantBytes: int64(len(fineTuneLine)),
+			wantLines: 1,
+		},
+		{
+			name: "expires after",
+			fields: []field{
+				{name: "purpose", value: purposeFineTune},
+				{name: "expires_after[anchor]", value: "created_at"},
+				{name: "expires_after[seconds]", value: "3600"},
+				{name: "file", filename: "test.jsonl", value: fineTuneLine},
+			},
+			wantCode:  http.StatusCreated,
+			wantBytes: int64(len(fineTuneLine)),
+			wantLines: 1,
+		},
+		{
+			name: "invalid expires after anchor",
+			fields: []field{
+				{name: "purpose", value: purposeFineTune},
+				{name: "file", filename: "test.jsonl", value: fineTuneLine},
+				{name: "expires_after[anchor]", value: "last_active_at"},
+				{name: "expires_after[seconds]", value: "3600"},
+			},
+			wantCode:      http.StatusBadRequest,
+			wantDeletions: 1,
+		},
+		{
+			name: "too short expires after",
+			fields: []field{
+				{name: "purpose", value: purposeFineTune},
+				{name: "file", filename: "test.jsonl", value: fineTuneLine},
+				{name: "expires_after[anchor]", value: "created_at"},
+				{name: "expires_after[seconds]", value: "60"},
+			},
+			wantCode:      http.StatusBadRequest,
+			wantDeletions: 1,
+		},
+		{
+			name: "missing expires after seconds",
+			fields: []field{
+				{name: "purpose", value: purposeFineTune},
+				{name: "file", filename: "test.jsonl", value: fineTuneLine},
+				{name: "expires_after[anchor]", value: "created_at"},
+			},
+			wantCode:      http.StatusBadRequest,
+			wantDeletions: 1,
+		},
+		{
+			name: "missing purpose",
+			fields: []field{
+				{name: "file", filename: "test.jsonl", value: "hello"},
+			},
+			wantCode:      http.StatusBadRequest,
+			wantDeletions: 1,
+		},
+		{
+			name: "invalid purpose after file",
+			fields: []field{
+				{name: "file", filename: "test.jsonl", value: "hello"},
+				{name: "purpose", value: "invalid"},
+			},
+			wantCode:      http.StatusBadRequest,
+			wantDeletions: 1,
+		},
+		{
+			name: "invalid purpose before file",
+			fields: []field{
+				{name: "purpose", value: "invalid"},
+				{name: "file", filename: "test.jsonl", value: "hello"},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "missing file",
+			fields: []field{
+				{name: "purpose", value: purposeFineTune},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "batch purpose",
+			fields: []field{
+				{name: "purpose", value: purposeBatch},
+				{name: "file", filename: "batch.jsonl", value: batchLine},
+			},
+			wantCode:  http.StatusCreated,
+			wantBytes: int64(len(batchLine)),
+			wantLines: 1,
+		},
+		{
+			name: "assistants purpose without validation",
+			fields: []field{
+				{name: "purpose", value: purposeAssistants},
+				{name: "file", filename: "test.txt", value: "hello"},
+			},
+			wantCode:  http.StatusCreated,
+			wantBytes: 5,
+		},
+		{
+			name: "invalid fine-tune content",
+			fields: []field{
+				{name: "purpose", value: purposeFineTune},
+				{name: "file", filename: "test.jsonl", value: fineTuneLine + "\nhello\n" + fineTuneLine},
+			},
+			wantCode:      http.StatusBadRequest,
+			wantBody:      "invalid fine-tune file: line 2: invalid JSON",
+			wantDeletions: 1,
+		},
+		{
+			name: "invalid fine-tune content before purpose",
+			fields: []field{
+				{name: "file", filename: "test.jsonl", value: `{"messages": []}`},
+				{name: "purpose", value: purposeFineTune},
+			},
+			wantCode:      http.StatusBadRequest,
+			wantBody:      "invalid fine-tune file: line 1: messages must be a non-empty array",
+			wantDeletions: 1,
+		},
+		{
+			name: "too many errors",
+			fields: []field{
+				{name: "purpose", value: purposeFineTune},
+				{name: "file", filename: "test.jsonl", value: strings.Repeat("hello\n", 100)},
+			},
+			wantCode:      http.StatusBadRequest,
+			wantBody:      "line 10: invalid JSON; stopped after 10 errors",
+			wantDeletions: 1,
+		},
+		{
+			name: "invalid batch content",
+			fields: []field{
+				{name: "purpose", value: purposeBatch},
+				{name: "file", filename: "batch.jsonl", value: batchLine + "\n" + batchLine},
+			},
+			wantCode:      http.StatusBadRequest,
+			wantBody:      `{"error":{"message":"invalid batch file: line 2: duplicate custom_id \"request-1\". it is already used at line 1","type":"invalid_request_error","param":"file","code":"invalid_file_content","line":2}}`,
+			wantDeletions: 1,
+		},
+		{
+			name: "output purpose",
+			fields: []field{
+				{name: "purpose", value: purposeBatchOutput},
+				{name: "file", filename: "batch.jsonl", value: "hello"},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid extension before file",
+			fields: []field{
+				{name: "purpose", value: purposeVision},
+				{name: "file", filename: "test.jsonl", value: "hello"},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid extension after file",
+			fields: []field{
+				{name: "file", filename: "test.txt", value: "hello"},
+				{name: "purpose", value: purposeFineTune},
+			},
+			wantCode:      http.StatusBadRequest,
+			wantDeletions: 1,
+		},
+		{
+			name: "too large file",
+			fields: []field{
+				{name: "purpose", value: purposeVision},
+				{name: "file", filename: "image.png", value: strings.Repeat("a", int(purposeRules[purposeVision].maxBytes)+1)},
+			},
+			wantCode:      http.StatusRequestEntityTooLarge,
+			wantDeletions: 1,
+		},
+		{
+			name: "too large file before purpose",
+			fields: []field{
+				{name: "file", filename: "image.png", value: strings.Repeat("a", int(purposeRules[purposeVision].maxBytes)+1)},
+				{name: "purpose", value: purposeVision},
+			},
+			wantCode:      http.StatusRequestEntityTooLarge,
+			wantDeletions: 1,
+		},
+		{
+			name: "multiple files",
+			fields: []field{
+				{name: "purpose", value: purposeFineTune},
+				{name: "file", filename: "test0.jsonl", value: "hello"},
+				{name: "file", filename: "test1.jsonl", value: "hello"},
+			},
+			wantCode:      http.StatusBadRequest,
+			wantDeletions: 1,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			st, tearDown := store.NewTest(t)
+			defer tearDown()
+
+			objectStore := newMemoryObjectStore()
+			srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+
+			var b bytes.Buffer
+			w := multipart.NewWriter(&b)
+			for _, f := range tc.fields {
+				var fw io.Writer
+				var err error
+				if f.filename != "" {
+					fw, err = w.CreateFormFile(f.name, f.filename)
+				} else {
+					fw, err = w.CreateFormField(f.name)
+				}
+				assert.NoError(t, err)
+				_, err = fw.Write([]byte(f.value))
+				assert.NoError(t, err)
+			}
+			err := w.Close()
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("POST", "v1/files", &b)
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", w.FormDataContentType())
+			rr := httptest.NewRecorder()
+			srv.CreateFile(rr, req, nil)
+			assert.Equal(t, tc.wantCode, rr.Code, rr.Body.String())
+			assert.Contains(t, rr.Body.String(), tc.wantBody)
+
+			ds, err := st.ListDueObjectDeletions(time.Now(), 10)
+			assert.NoError(t, err)
+			assert.Len(t, ds, tc.wantDeletions)
+
+			if tc.wantCode != http.StatusCreated {
+				return
+			}
+			var fj fileJSON
+			err = json.Unmarshal(rr.Body.Bytes(), &fj)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantBytes, fj.Bytes)
+			f, err := st.GetFile(fj.ID, defaultProjectID)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantBytes, int64(len(objectStore.objs[f.ObjectStorePath])))
+			assert.Equal(t, tc.wantLines, f.Lines)
+		})
+	}
+}
+
+func TestGetFileContent(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	fw, err := w.CreateFormFile("file", "test-file.jsonl")
+	assert.NoError(t, err)
+	_, err = fw.Write([]byte("hello"))
+	assert.NoError(t, err)
+	err = w.WriteField("purpose", purposeAssistants)
+	assert.NoError(t, err)
+	err = w.Close()
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "v1/files", &b)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	rr := httptest.NewRecorder()
+	srv.CreateFile(rr, req, nil)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var fj fileJSON
+	err = json.Unmarshal(rr.Body.Bytes(), &fj)
+	assert.NoError(t, err)
+
+	objectStore.objs["s3://bucket/path/to/external.jsonl"] = []byte("external")
+	_, err = srv.CreateFileFromObjectPath(fakeAuthInto(context.Background()), &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/path/to/external.jsonl",
+		Purpose:    purposeFineTune,
+	})
+	assert.NoError(t, err)
+	listResp, err := srv.ListFiles(fakeAuthInto(context.Background()), &v1.ListFilesRequest{})
+	assert.NoError(t, err)
+	var externalID string
+	for _, f := range listResp.Data {
+		if f.Id != fj.ID {
+			externalID = f.Id
+		}
+	}
+
+	const missingID = "file-missing"
+	_, err = st.CreateFile(store.FileSpec{
+		FileID:          missingID,
+		TenantID:        defaultTenantID,
+		ProjectID:       defaultProjectID,
+		Filename:        "missing.jsonl",
+		Bytes:           5,
+		ObjectStorePath: "pathPrefix/missing",
+	})
+	assert.NoError(t, err)
+	const missingEmptyID = "file-missing-empty"
+	_, err = st.CreateFile(store.FileSpec{
+		FileID:          missingEmptyID,
+		TenantID:        defaultTenantID,
+		ProjectID:       defaultProjectID,
+		Filename:        "missing-empty.jsonl",
+		ObjectStorePath: "pathPrefix/missing-empty",
+	})
+	assert.NoError(t, err)
+
+	etag := fmt.Sprintf("%q", fmt.Sprintf("%x", md5.Sum([]byte("hello"))))
+	f, err := st.GetFile(fj.ID, defaultProjectID)
+	assert.NoError(t, err)
+	assert.Equal(t, etag, f.ETag)
+
+	tcs := []struct {
+		name          string
+		id            string
+		header        map[string]string
+		wantCode      int
+		wantBody      string
+		wantHeader    map[string]string
+		wantDownloads int
+	}{
+		{
+			name:     "success",
+			id:       fj.ID,
+			wantCode: http.StatusOK,
+			wantBody: "hello",
+			wantHeader: map[string]string{
+				"Content-Type":        "application/octet-stream",
+				"Content-Length":      "5",
+				"Content-Disposition": "attachment; filename=test-file.jsonl",
+				"Accept-Ranges":       "bytes",
+				"ETag":                etag,
+			},
+			wantDownloads: 1,
+		},
+		{
+			name:     "single range",
+			id:       fj.ID,
+			header:   map[string]string{"Range": "bytes=1-3"},
+			wantCode: http.StatusPartialContent,
+			wantBody: "ell",
+			wantHeader: map[string]string{
+				"Content-Length": "3",
+				"Content-Range":  "bytes 1-3/5",
+			},
+			wantDownloads: 1,
+		},
+		{
+			name:          "suffix range",
+			id:            fj.ID,
+			header:        map[string]string{"Range": "bytes=-2"},
+			wantCode:      http.StatusPartialContent,
+			wantBody:      "lo",
+			wantDownloads: 1,
+		},
+		{
+			name:     "unsatisfiable range",
+			id:       fj.ID,
+			header:   map[string]string{"Range": "bytes=10-20"},
+			wantCode: http.StatusRequestedRangeNotSatisfiable,
+		},
+		{
+			name:     "if-none-match",
+			id:       fj.ID,
+			header:   map[string]string{"If-None-Match": etag},
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name:          "if-none-match with a different etag",
+			id:            fj.ID,
+			header:        map[string]string{"If-None-Match": `"other"`},
+			wantCode:      http.StatusOK,
+			wantBody:      "hello",
+			wantDownloads: 1,
+		},
+		{
+			name:     "if-modified-since",
+			id:       fj.ID,
+			header:   map[string]string{"If-Modified-Since": f.CreatedAt.Add(time.Hour).UTC().Format(http.TimeFormat)},
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name:          "if-range with a stale etag",
+			id:            fj.ID,
+			header:        map[string]string{"Range": "bytes=1-3", "If-Range": `"other"`},
+			wantCode:      http.StatusOK,
+			wantBody:      "hello",
+			wantDownloads: 1,
+		},
+		{
+			name:     "not found",
+			id:       "file-unknown",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "external object path",
+			id:       externalID,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing object",
+			id:       missingID,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "missing object of an empty file",
+			id:       missingEmptyID,
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "v1/files/"+tc.id+"/content", nil)
+			assert.NoError(t, err)
+			for k, v := range tc.header {
+				req.Header.Set(k, v)
+			}
+			rr := httptest.NewRecorder()
+			objectStore.downloads = 0
+			srv.GetFileContent(rr, req, map[string]string{"id": tc.id})
+			assert.Equal(t, tc.wantCode, rr.Code)
+			// The content is downloaded only when it is sent.
+			assert.Equal(t, tc.wantDownloads, objectStore.downloads)
+			if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, rr.Body.String())
+			}
+			for k, v := range tc.wantHeader {
+				assert.Equal(t, v, rr.Header().Get(k), k)
+			}
+		})
+	}
+
+	t.Run("multiple ranges", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "v1/files/"+fj.ID+"/content", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Range", "bytes=0-1,3-4")
+		rr := httptest.NewRecorder()
+		srv.GetFileContent(rr, req, map[string]string{"id": fj.ID})
+		assert.Equal(t, http.StatusPartialContent, rr.Code)
+
+		mt, params, err := mime.ParseMediaType(rr.Header().Get("Content-Type"))
+		assert.NoError(t, err)
+		assert.Equal(t, "multipart/byteranges", mt)
+		mr := multipart.NewReader(rr.Body, params["boundary"])
+		var got []string
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			b, err := io.ReadAll(p)
+			assert.NoError(t, err)
+			got = append(got, string(b))
+		}
+		assert.Equal(t, []string{"he", "lo"}, got)
+	})
+}
+
 func TestCreateFileWithUploadFlag(t *testing.T) {
 	tcs := []struct {
 		name             string
@@ -129,6 +994,7 @@ func TestCreateFileWithUploadFlag(t *testing.T) {
 		{
 			name:             "file upload disabled",
 			enableFileUpload: false,
+			wantErr:          true,
 		},
 	}
 
@@ -137,7 +1003,7 @@ func TestCreateFileWithUploadFlag(t *testing.T) {
 			st, tearDown := store.NewTest(t)
 			defer tearDown()
 
-			srv := New(st, &NoopS3Client{}, &sender.NoopUsageSetter{}, "pathPrefix", tc.enableFileUpload, testr.New(t))
+			srv := New(st, newMemoryObjectStore(), &sender.NoopUsageSetter{}, "pathPrefix", tc.enableFileUpload, config.QuotaConfig{}, false, testr.New(t))
 			rr := httptest.NewRecorder()
 			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				srv.CreateFile(w, r, nil)
@@ -148,7 +1014,7 @@ func TestCreateFileWithUploadFlag(t *testing.T) {
 
 			fw, err := w.CreateFormFile("file", "test-file.jsonl")
 			assert.NoError(t, err)
-			_, err = fw.Write([]byte("hello"))
+			_, err = fw.Write([]byte(fineTuneLine))
 			assert.NoError(t, err)
 
 			fw, err = w.CreateFormField("purpose")
@@ -165,8 +1031,15 @@ func TestCreateFileWithUploadFlag(t *testing.T) {
 
 			handler.ServeHTTP(rr, req)
 
+			caps, err := srv.GetFileCapabilities(fakeAuthInto(context.Background()), &v1.GetFileCapabilitiesRequest{})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.enableFileUpload, caps.FileUploadEnabled)
+
 			if tc.wantErr {
 				assert.Equal(t, http.StatusForbidden, rr.Code)
+				n, err := st.CountFilesByProjectID(defaultProjectID)
+				assert.NoError(t, err)
+				assert.Zero(t, n)
 				return
 			}
 			assert.Equal(t, http.StatusCreated, rr.Code)
@@ -177,7 +1050,7 @@ func TestCreateFileWithUploadFlag(t *testing.T) {
 			assert.NotEmpty(t, fj.ID)
 			assert.Equal(t, purposeFineTune, fj.Purpose)
 			assert.Equal(t, "test-file.jsonl", fj.Filename)
-			assert.Equal(t, int64(5), fj.Bytes)
+			assert.Equal(t, int64(len(fineTuneLine)), fj.Bytes)
 		})
 	}
 }
@@ -216,7 +1089,7 @@ func TestCreateFileFromObjectPath(t *testing.T) {
 	st, tearDown := store.NewTest(t)
 	defer tearDown()
 
-	srv := New(st, &NoopS3Client{}, &sender.NoopUsageSetter{}, "pathPrefix", true, testr.New(t))
+	srv := New(st, &NoopObjectStore{}, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
 	ctx := fakeAuthInto(context.Background())
 
 	// Test successful creation
@@ -229,12 +1102,29 @@ func TestCreateFileFromObjectPath(t *testing.T) {
 	assert.Equal(t, "test-file.jsonl", resp.Filename)
 	assert.Equal(t, int64(0), resp.Bytes)
 	assert.Equal(t, purposeFineTune, resp.Purpose)
+	// The object cannot be looked up without the object store.
+	assert.Equal(t, string(store.ObjectVerificationStatusPending), resp.ObjectVerificationStatus)
 
 	// Verify the file exists in the store
 	f, err := st.GetFile(resp.Id, defaultProjectID)
 	assert.NoError(t, err)
 	assert.Equal(t, "s3://bucket/path/to/test-file.jsonl", f.ObjectStorePath)
 
+	// The extension is not validated for registered objects.
+	resp, err = srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/path/to/part-00000",
+		Purpose:    purposeFineTune,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "part-00000", resp.Filename)
+
+	// Test an object path without a key
+	_, err = srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket",
+		Purpose:    purposeFineTune,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
 	// Test missing object path
 	_, err = srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
 		Purpose: purposeFineTune,
@@ -250,3 +1140,150 @@ func TestCreateFileFromObjectPath(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
+
+func TestCreateFileFromObjectPathWithObjectVerification(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	lastModified := time.Unix(1700000000, 0).UTC()
+	objectStore := &memoryObjectStore{
+		objs: map[string][]byte{
+			"s3://bucket/path/to/test-file.jsonl": []byte("hello"),
+		},
+		lastModified: map[string]time.Time{
+			"s3://bucket/path/to/test-file.jsonl": lastModified,
+		},
+	}
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	resp, err := srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/path/to/test-file.jsonl",
+		Purpose:    purposeFineTune,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), resp.Bytes)
+	assert.Equal(t, string(store.ObjectVerificationStatusVerified), resp.ObjectVerificationStatus)
+	assert.Equal(t, string(store.FileStatusProcessed), resp.Status)
+
+	f, err := st.GetFile(resp.Id, defaultProjectID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, f.ETag)
+	assert.True(t, lastModified.Equal(f.ObjectLastModifiedAt))
+
+	_, err = srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/path/to/missing.jsonl",
+		Purpose:    purposeFineTune,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// The content of a batch input file is validated.
+	objectStore.objs["s3://bucket/batch.jsonl"] = []byte(batchLine + "\n")
+	resp, err = srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/batch.jsonl",
+		Purpose:    purposeBatch,
+	})
+	assert.NoError(t, err)
+	f, err = st.GetFile(resp.Id, defaultProjectID)
+	assert.NoError(t, err)
+	assert.Equal(t, store.ValidationStatusValid, f.ValidationStatus)
+	assert.Equal(t, int64(1), f.Lines)
+
+	objectStore.objs["s3://bucket/invalid-batch.jsonl"] = []byte(`{"custom_id": "r0"}`)
+	_, err = srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/invalid-batch.jsonl",
+		Purpose:    purposeBatch,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	details := status.Convert(err).Details()
+	assert.Len(t, details, 1)
+	br, ok := details[0].(*errdetails.BadRequest)
+	assert.True(t, ok)
+	assert.Equal(t, "file:line 1", br.FieldViolations[0].Field)
+
+	// The verification is deferred to a worker cluster when the object store is not accessible.
+	objectStore.statErr = fmt.Errorf("access denied")
+	resp, err = srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://other-bucket/test-file.jsonl",
+		Purpose:    purposeFineTune,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, string(store.ObjectVerificationStatusPending), resp.ObjectVerificationStatus)
+	assert.Equal(t, string(store.FileStatusUploaded), resp.Status)
+	missing, err := srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://other-bucket/missing.jsonl",
+		Purpose:    purposeFineTune,
+	})
+	assert.NoError(t, err)
+
+	lfresp, err := srv.ListFiles(ctx, &v1.ListFilesRequest{Status: string(store.FileStatusUploaded)})
+	assert.NoError(t, err)
+	assert.Len(t, lfresp.Data, 2)
+	_, err = srv.ListFiles(ctx, &v1.ListFilesRequest{Status: "invalid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	wsrv := NewWorkerServiceServer(st, testr.New(t))
+	wctx := context.Background()
+	lresp, err := wsrv.ListFilesPendingObjectVerification(wctx, &v1.ListFilesPendingObjectVerificationRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, lresp.Files, 2)
+	assert.Equal(t, resp.Id, lresp.Files[0].Id)
+
+	_, err = wsrv.UpdateFileObjectVerification(wctx, &v1.UpdateFileObjectVerificationRequest{
+		Id:             resp.Id,
+		Exists:         true,
+		Bytes:          10,
+		Etag:           "etag",
+		LastModifiedAt: lastModified.Unix(),
+	})
+	assert.NoError(t, err)
+
+	got, err := srv.GetFile(ctx, &v1.GetFileRequest{Id: resp.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), got.Bytes)
+	assert.Equal(t, string(store.ObjectVerificationStatusVerified), got.ObjectVerificationStatus)
+	assert.Equal(t, string(store.FileStatusProcessed), got.Status)
+
+	_, err = wsrv.UpdateFileObjectVerification(wctx, &v1.UpdateFileObjectVerificationRequest{
+		Id: missing.Id,
+	})
+	assert.NoError(t, err)
+	got, err = srv.GetFile(ctx, &v1.GetFileRequest{Id: missing.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, string(store.ObjectVerificationStatusNotFound), got.ObjectVerificationStatus)
+	assert.Equal(t, string(store.FileStatusError), got.Status)
+	assert.NotEmpty(t, got.StatusDetails)
+
+	lfresp, err = srv.ListFiles(ctx, &v1.ListFilesRequest{Status: string(store.FileStatusUploaded)})
+	assert.NoError(t, err)
+	assert.Empty(t, lfresp.Data)
+
+	// The file is no longer pending verification.
+	_, err = wsrv.UpdateFileObjectVerification(wctx, &v1.UpdateFileObjectVerificationRequest{
+		Id: resp.Id,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	lresp, err = wsrv.ListFilesPendingObjectVerification(wctx, &v1.ListFilesPendingObjectVerificationRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, lresp.Files)
+}
+
+func TestHTTPStatusCode(t *testing.T) {
+	tcs := []struct {
+		code codes.Code
+		want int
+	}{
+		{code: codes.InvalidArgument, want: http.StatusBadRequest},
+		{code: codes.ResourceExhausted, want: http.StatusRequestEntityTooLarge},
+		{code: codes.NotFound, want: http.StatusNotFound},
+		{code: codes.FailedPrecondition, want: http.StatusConflict},
+		{code: codes.PermissionDenied, want: http.StatusForbidden},
+		{code: codes.Unimplemented, want: http.StatusNotImplemented},
+		{code: codes.Internal, want: http.StatusInternalServerError},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.code.String(), func(t *testing.T) {
+			assert.Equal(t, tc.want, httpStatusCode(status.Error(tc.code, "error")))
+		})
+	}
+}
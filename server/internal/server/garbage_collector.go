@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/llmariner/common/pkg/id"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"gorm.io/gorm"
+)
+
+const (
+	// gcBatchSize is the number of listed objects checked against the database at once.
+	gcBatchSize = 1000
+	// gcLeaseName is the name of the lease held by the replica running the garbage collection.
+	gcLeaseName = "garbage-collector"
+)
+
+// NewGarbageCollector creates a new garbage collector. bucket is the S3 bucket of the object store. It is empty
+// if the object store is not S3.
+func NewGarbageCollector(
+	st *store.S,
+	objectStore ObjectStore,
+	bucket string,
+	pathPrefix string,
+	gracePeriod time.Duration,
+	dryRun bool,
+	log logr.Logger,
+) *GarbageCollector {
+	return &GarbageCollector{
+		store:       st,
+		objectStore: objectStore,
+		bucket:      bucket,
+		pathPrefix:  pathPrefix,
+		gracePeriod: gracePeriod,
+		dryRun:      dryRun,
+		log:         log.WithName("gc"),
+	}
+}
+
+// GarbageCollector deletes orphaned objects under the path prefix, i.e., objects that
+// are not referenced by any file. Orphaned objects are left when the file creation fails
+// after the upload.
+type GarbageCollector struct {
+	store       *store.S
+	objectStore ObjectStore
+	bucket      string
+	pathPrefix  string
+	gracePeriod time.Duration
+	dryRun      bool
+	log         logr.Logger
+}
+
+// Run periodically runs the garbage collection. The garbage collection runs in only one replica at a time
+// as the replica holding the lease runs it.
+func (g *GarbageCollector) Run(ctx context.Context, interval time.Duration) error {
+	g.log.Info("Starting garbage collector...", "interval", interval, "gracePeriod", g.gracePeriod, "dryRun", g.dryRun)
+	holder, err := id.GenerateID("gc-", 16)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// Hold the lease longer than the interval so that the holder renews it before it expires.
+		if ok, err := g.store.AcquireLease(gcLeaseName, holder, time.Now(), 2*interval); err != nil {
+			g.log.Error(err, "Failed to acquire the lease")
+		} else if !ok {
+			g.log.V(1).Info("Skipped garbage collection as another replica holds the lease")
+		} else if _, err := g.Collect(ctx, time.Now()); err != nil {
+			g.log.Error(err, "Failed to collect garbage")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect deletes orphaned objects that are older than the grace period. It returns the keys
+// of the deleted objects, or the keys of the objects that would be deleted in the dry-run mode.
+//
+// Listed objects are checked against the database in batches so that the memory usage does not
+// grow with the number of objects.
+func (g *GarbageCollector) Collect(ctx context.Context, now time.Time) ([]string, error) {
+	prefix := g.pathPrefix + "/"
+	var (
+		batch     []string
+		collected []string
+		listed    int
+	)
+	if err := g.objectStore.List(ctx, prefix, func(key string, lastModified time.Time) error {
+		listed++
+		if now.Sub(lastModified) < g.gracePeriod {
+			return nil
+		}
+		batch = append(batch, key)
+		if len(batch) < gcBatchSize {
+			return nil
+		}
+		keys, err := g.collectBatch(ctx, prefix, batch)
+		collected = append(collected, keys...)
+		batch = batch[:0]
+		return err
+	}); err != nil {
+		return collected, err
+	}
+	keys, err := g.collectBatch(ctx, prefix, batch)
+	collected = append(collected, keys...)
+	if err != nil {
+		return collected, err
+	}
+	g.log.Info("Completed garbage collection", "listed", listed, "orphans", len(collected), "dryRun", g.dryRun)
+	return collected, nil
+}
+
+// objectStorePaths returns the paths with which files refer to the object of the key. Files registered with
+// CreateFileFromObjectPath refer to objects in the bucket with their URIs instead of their keys.
+func (g *GarbageCollector) objectStorePaths(key string) []string {
+	if g.bucket == "" {
+		return []string{key}
+	}
+	return []string{key, fmt.Sprintf("s3://%s/%s", g.bucket, key)}
+}
+
+// collectBatch deletes the objects in the batch that are referenced by neither files nor presigned uploads.
+func (g *GarbageCollector) collectBatch(ctx context.Context, prefix string, keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	// keysByPath maps the paths referring to the objects to their keys.
+	keysByPath := map[string]string{}
+	var paths []string
+	for _, key := range keys {
+		for _, p := range g.objectStorePaths(key) {
+			keysByPath[p] = key
+			paths = append(paths, p)
+		}
+	}
+	refPaths, err := g.store.ListReferencedObjectStorePaths(paths)
+	if err != nil {
+		return nil, err
+	}
+	referenced := map[string]bool{}
+	for _, p := range refPaths {
+		referenced[keysByPath[p]] = true
+	}
+
+	// Objects uploaded with presigned URLs are kept until their uploads are finalized or expire.
+	var fileIDs []string
+	for _, key := range keys {
+		if fileID := strings.TrimPrefix(key, prefix); !strings.Contains(fileID, "/") {
+			fileIDs = append(fileIDs, fileID)
+		}
+	}
+	ids, err := g.store.ListPresignedUploadFileIDs(fileIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, fileID := range ids {
+		referenced[prefix+fileID] = true
+	}
+
+	var collected []string
+	for _, key := range keys {
+		if referenced[key] {
+			continue
+		}
+		if g.dryRun {
+			g.log.Info("Found an orphaned object (dry run)", "key", key)
+			collected = append(collected, key)
+			continue
+		}
+		// Check again as the file might have been created after the lookup.
+		if ok, err := g.isReferenced(key); err != nil {
+			return collected, err
+		} else if ok {
+			continue
+		}
+		if err := g.objectStore.Delete(ctx, key); err != nil {
+			return collected, err
+		}
+		g.log.Info("Deleted an orphaned object", "key", key)
+		collected = append(collected, key)
+	}
+	return collected, nil
+}
+
+// isReferenced returns true if a file refers to the object of the key.
+func (g *GarbageCollector) isReferenced(key string) (bool, error) {
+	for _, p := range g.objectStorePaths(key) {
+		if _, err := g.store.GetFileByObjectStorePath(p); err == nil {
+			return true, nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, err
+		}
+	}
+	return false, nil
+}
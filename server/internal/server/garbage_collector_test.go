@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGarbageCollector(t *testing.T) {
+	now := time.Now()
+
+	tcs := []struct {
+		name        string
+		dryRun      bool
+		wantKeys    []string
+		wantObjects []string
+	}{
+		{
+			name:        "delete",
+			wantKeys:    []string{"pathPrefix/orphan"},
+			wantObjects: []string{"other/orphan", "pathPrefix/f0", "pathPrefix/file-pending", "pathPrefix/recent", "pathPrefix/registered"},
+		},
+		{
+			name:        "dry run",
+			dryRun:      true,
+			wantKeys:    []string{"pathPrefix/orphan"},
+			wantObjects: []string{"other/orphan", "pathPrefix/f0", "pathPrefix/file-pending", "pathPrefix/orphan", "pathPrefix/recent", "pathPrefix/registered"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			st, tearDown := store.NewTest(t)
+			defer tearDown()
+
+			objectStore := &memoryObjectStore{
+				objs: map[string][]byte{
+					"pathPrefix/f0":           {},
+					"pathPrefix/file-pending": {},
+					"pathPrefix/orphan":       {},
+					"pathPrefix/recent":       {},
+					"pathPrefix/registered":   {},
+					"other/orphan":            {},
+				},
+				lastModified: map[string]time.Time{
+					"pathPrefix/recent": now.Add(-time.Minute),
+				},
+			}
+			_, err := st.CreateFile(store.FileSpec{
+				FileID:          "f0",
+				ProjectID:       defaultProjectID,
+				ObjectStorePath: "pathPrefix/f0",
+			})
+			assert.NoError(t, err)
+			// The object is registered with CreateFileFromObjectPath.
+			_, err = st.CreateFile(store.FileSpec{
+				FileID:          "f1",
+				ProjectID:       defaultProjectID,
+				ObjectStorePath: "s3://bucket/pathPrefix/registered",
+			})
+			assert.NoError(t, err)
+			// The object uploaded with a presigned URL has not been finalized.
+			err = st.CreatePresignedUpload(&store.PresignedUpload{
+				FileID:    "file-pending",
+				ProjectID: defaultProjectID,
+				ExpiresAt: now.Add(time.Hour),
+			})
+			assert.NoError(t, err)
+
+			g := NewGarbageCollector(st, objectStore, "bucket", "pathPrefix", time.Hour, tc.dryRun, testr.New(t))
+			keys, err := g.Collect(context.Background(), now)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tc.wantKeys, keys)
+
+			var objs []string
+			for k := range objectStore.objs {
+				objs = append(objs, k)
+			}
+			assert.ElementsMatch(t, tc.wantObjects, objs)
+		})
+	}
+}
+
+func TestGarbageCollectorBatches(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	for i := 0; i < 2*gcBatchSize+1; i++ {
+		objectStore.objs[fmt.Sprintf("pathPrefix/orphan%d", i)] = nil
+	}
+	objectStore.objs["pathPrefix/f0"] = nil
+	_, err := st.CreateFile(store.FileSpec{
+		FileID:          "f0",
+		ProjectID:       defaultProjectID,
+		ObjectStorePath: "pathPrefix/f0",
+	})
+	assert.NoError(t, err)
+
+	g := NewGarbageCollector(st, objectStore, "bucket", "pathPrefix", time.Hour, false, testr.New(t))
+	keys, err := g.Collect(context.Background(), time.Now())
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2*gcBatchSize+1)
+	assert.Len(t, objectStore.objs, 1)
+	assert.Contains(t, objectStore.objs, "pathPrefix/f0")
+}
+
+func TestGarbageCollectorLease(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	// Another replica holds the lease.
+	ok, err := st.AcquireLease(gcLeaseName, "other", time.Now(), time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	objectStore := newMemoryObjectStore()
+	objectStore.objs["pathPrefix/orphan"] = nil
+	g := NewGarbageCollector(st, objectStore, "bucket", "pathPrefix", time.Hour, false, testr.New(t))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = g.Run(ctx, time.Minute)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, objectStore.objs, "pathPrefix/orphan")
+}
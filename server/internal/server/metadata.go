@@ -0,0 +1,107 @@
+package server
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/llmariner/file-manager/server/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxMetadataPairs, maxMetadataKeyLength, and maxMetadataValueLength are the limits of the metadata
+	// of a file. They are the same as the limits of the OpenAI API.
+	maxMetadataPairs       = 16
+	maxMetadataKeyLength   = 64
+	maxMetadataValueLength = 512
+
+	// metadataFormPrefix and metadataFormSuffix enclose the key of a metadata form field
+	// (e.g., "metadata[team]") of CreateFile.
+	metadataFormPrefix = "metadata["
+	metadataFormSuffix = "]"
+)
+
+// metadataKeyPattern is the pattern of metadata keys. Keys are restricted so that they can be used in selectors.
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.:/-]+$`)
+
+// validateMetadata validates the metadata of a file.
+func validateMetadata(md map[string]string) error {
+	if len(md) > maxMetadataPairs {
+		return status.Errorf(codes.InvalidArgument, "metadata can have at most %d key-value pairs", maxMetadataPairs)
+	}
+	for k, v := range md {
+		if err := validateMetadataKey(k); err != nil {
+			return err
+		}
+		if len(v) > maxMetadataValueLength {
+			return status.Errorf(codes.InvalidArgument, "metadata value of %q must be at most %d characters", k, maxMetadataValueLength)
+		}
+	}
+	return nil
+}
+
+// addMetadataFormPair validates a key-value pair given as a form field of CreateFile and adds it to the metadata.
+// The pair is validated as soon as it is parsed so that the request is rejected before the file is uploaded.
+func addMetadataFormPair(md map[string]string, k, v string) error {
+	if _, ok := md[k]; !ok && len(md) >= maxMetadataPairs {
+		return status.Errorf(codes.InvalidArgument, "metadata can have at most %d key-value pairs", maxMetadataPairs)
+	}
+	if err := validateMetadataKey(k); err != nil {
+		return err
+	}
+	if len(v) > maxMetadataValueLength {
+		return status.Errorf(codes.InvalidArgument, "metadata value of %q must be at most %d characters", k, maxMetadataValueLength)
+	}
+	md[k] = v
+	return nil
+}
+
+func validateMetadataKey(k string) error {
+	if len(k) > maxMetadataKeyLength {
+		return status.Errorf(codes.InvalidArgument, "metadata key %q must be at most %d characters", k, maxMetadataKeyLength)
+	}
+	if !metadataKeyPattern.MatchString(k) {
+		return status.Errorf(codes.InvalidArgument, "invalid metadata key: %q. must consist of alphanumeric characters, '_', '.', ':', '/', or '-'", k)
+	}
+	return nil
+}
+
+// metadataFormKey returns the metadata key of a form field of CreateFile. It returns false if the field is not metadata.
+func metadataFormKey(name string) (string, bool) {
+	if !strings.HasPrefix(name, metadataFormPrefix) || !strings.HasSuffix(name, metadataFormSuffix) {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(name, metadataFormPrefix), metadataFormSuffix), true
+}
+
+// parseMetadataSelector parses a comma-separated list of requirements on the metadata. A requirement is
+// one of "key=value", "key==value", "key!=value", "key", and "!key".
+func parseMetadataSelector(selector string) ([]store.MetadataRequirement, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+	var reqs []store.MetadataRequirement
+	for _, s := range strings.Split(selector, ",") {
+		s = strings.TrimSpace(s)
+		var r store.MetadataRequirement
+		if k, v, ok := strings.Cut(s, "!="); ok {
+			r = store.MetadataRequirement{Key: k, Operator: store.MetadataOperatorNotEquals, Value: v}
+		} else if k, v, ok := strings.Cut(s, "=="); ok {
+			r = store.MetadataRequirement{Key: k, Operator: store.MetadataOperatorEquals, Value: v}
+		} else if k, v, ok := strings.Cut(s, "="); ok {
+			r = store.MetadataRequirement{Key: k, Operator: store.MetadataOperatorEquals, Value: v}
+		} else if k, ok := strings.CutPrefix(s, "!"); ok {
+			r = store.MetadataRequirement{Key: k, Operator: store.MetadataOperatorDoesNotExist}
+		} else {
+			r = store.MetadataRequirement{Key: s, Operator: store.MetadataOperatorExists}
+		}
+		r.Key = strings.TrimSpace(r.Key)
+		r.Value = strings.TrimSpace(r.Value)
+		if err := validateMetadataKey(r.Key); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid metadata_selector %q: %s", s, statusMessage(err))
+		}
+		reqs = append(reqs, r)
+	}
+	return reqs, nil
+}
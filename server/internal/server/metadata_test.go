@@ -0,0 +1,201 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/llmariner/api-usage/pkg/sender"
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestFileMetadata(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	objectStore.objs["s3://bucket/path/to/test-file.jsonl"] = []byte("{}\n")
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	createFile := func(fields map[string]string) (int, *fileJSON) {
+		var b bytes.Buffer
+		w := multipart.NewWriter(&b)
+		for k, v := range fields {
+			err := w.WriteField(k, v)
+			assert.NoError(t, err)
+		}
+		fw, err := w.CreateFormFile("file", "test.txt")
+		assert.NoError(t, err)
+		_, err = fw.Write([]byte("hello"))
+		assert.NoError(t, err)
+		err = w.Close()
+		assert.NoError(t, err)
+		req, err := http.NewRequest("POST", "v1/files", &b)
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		rr := httptest.NewRecorder()
+		srv.CreateFile(rr, req, nil)
+		if rr.Code != http.StatusCreated {
+			return rr.Code, nil
+		}
+		var fj fileJSON
+		err = json.Unmarshal(rr.Body.Bytes(), &fj)
+		assert.NoError(t, err)
+		return rr.Code, &fj
+	}
+
+	code, f0 := createFile(map[string]string{
+		"purpose":          purposeAssistants,
+		"metadata[team]":   "ml",
+		"metadata[source]": "web",
+	})
+	assert.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, map[string]string{"team": "ml", "source": "web"}, f0.Metadata)
+
+	code, _ = createFile(map[string]string{
+		"purpose":               purposeAssistants,
+		"metadata[invalid key]": "ml",
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// The metadata is rejected before the file is uploaded.
+	numObjs := len(objectStore.objs)
+	tooManyFields := map[string]string{"purpose": purposeAssistants}
+	for i := 0; i <= maxMetadataPairs; i++ {
+		tooManyFields[fmt.Sprintf("metadata[key%d]", i)] = "value"
+	}
+	code, _ = createFile(tooManyFields)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = createFile(map[string]string{
+		"purpose":       purposeAssistants,
+		"metadata[key]": strings.Repeat("v", maxMetadataValueLength+1),
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Len(t, objectStore.objs, numObjs)
+
+	f1, err := srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/path/to/test-file.jsonl",
+		Purpose:    purposeFineTune,
+		Metadata:   map[string]string{"team": "infra"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "infra"}, f1.Metadata)
+
+	got, err := srv.GetFile(ctx, &v1.GetFileRequest{Id: f0.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "ml", "source": "web"}, got.Metadata)
+
+	listIDs := func(selector string) []string {
+		resp, err := srv.ListFiles(ctx, &v1.ListFilesRequest{MetadataSelector: selector})
+		assert.NoError(t, err)
+		var ids []string
+		for _, f := range resp.Data {
+			ids = append(ids, f.Id)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{f1.Id, f0.ID}, listIDs(""))
+	assert.Equal(t, []string{f0.ID}, listIDs("team=ml"))
+	assert.Equal(t, []string{f1.Id}, listIDs("team=infra, !source"))
+
+	updated, err := srv.UpdateFile(ctx, &v1.UpdateFileRequest{
+		File: &v1.File{
+			Id:       f0.ID,
+			Metadata: map[string]string{"team": "infra"},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"metadata"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "infra"}, updated.Metadata)
+	assert.Equal(t, []string{f1.Id, f0.ID}, listIDs("team=infra"))
+
+	tooMany := map[string]string{}
+	for i := 0; i <= maxMetadataPairs; i++ {
+		tooMany[fmt.Sprintf("key%d", i)] = "value"
+	}
+	for _, md := range []map[string]string{
+		tooMany,
+		{strings.Repeat("k", maxMetadataKeyLength+1): "value"},
+		{"key": strings.Repeat("v", maxMetadataValueLength+1)},
+		{"": "value"},
+	} {
+		_, err = srv.UpdateFile(ctx, &v1.UpdateFileRequest{
+			File:       &v1.File{Id: f0.ID, Metadata: md},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"metadata"}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	_, err = srv.ListFiles(ctx, &v1.ListFilesRequest{MetadataSelector: "team=ml,"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestParseMetadataSelector(t *testing.T) {
+	tcs := []struct {
+		selector string
+		want     []store.MetadataRequirement
+		wantErr  bool
+	}{
+		{
+			selector: "",
+		},
+		{
+			selector: "team=ml",
+			want: []store.MetadataRequirement{
+				{Key: "team", Operator: store.MetadataOperatorEquals, Value: "ml"},
+			},
+		},
+		{
+			selector: "team==ml, source != web,experiment,!deprecated",
+			want: []store.MetadataRequirement{
+				{Key: "team", Operator: store.MetadataOperatorEquals, Value: "ml"},
+				{Key: "source", Operator: store.MetadataOperatorNotEquals, Value: "web"},
+				{Key: "experiment", Operator: store.MetadataOperatorExists},
+				{Key: "deprecated", Operator: store.MetadataOperatorDoesNotExist},
+			},
+		},
+		{
+			selector: "team=",
+			want: []store.MetadataRequirement{
+				{Key: "team", Operator: store.MetadataOperatorEquals, Value: ""},
+			},
+		},
+		{
+			selector: "=ml",
+			wantErr:  true,
+		},
+		{
+			selector: "team=ml,,source",
+			wantErr:  true,
+		},
+		{
+			selector: "!",
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.selector, func(t *testing.T) {
+			got, err := parseMetadataSelector(tc.selector)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
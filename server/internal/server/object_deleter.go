@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/llmariner/file-manager/server/internal/store"
+)
+
+const (
+	objectDeletionBatchSize = 100
+
+	objectDeletionInitialBackoff = 30 * time.Second
+	objectDeletionMaxBackoff     = time.Hour
+)
+
+// NewObjectDeleter creates a new object deleter.
+func NewObjectDeleter(st *store.S, objectStore ObjectStore, log logr.Logger) *ObjectDeleter {
+	return &ObjectDeleter{
+		store:       st,
+		objectStore: objectStore,
+		log:         log.WithName("deleter"),
+	}
+}
+
+// ObjectDeleter deletes objects that have been enqueued for deletion. Failed deletions are
+// retried with exponential backoff.
+type ObjectDeleter struct {
+	store       *store.S
+	objectStore ObjectStore
+	log         logr.Logger
+}
+
+// Run periodically processes the deletion queue.
+func (d *ObjectDeleter) Run(ctx context.Context, interval time.Duration) error {
+	d.log.Info("Starting object deleter...", "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := d.processDueDeletions(ctx, time.Now()); err != nil {
+			d.log.Error(err, "Failed to process object deletions")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *ObjectDeleter) processDueDeletions(ctx context.Context, now time.Time) error {
+	ds, err := d.store.ListDueObjectDeletions(now, objectDeletionBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, od := range ds {
+		if err := d.objectStore.Delete(ctx, od.ObjectStorePath); err != nil {
+			backoff := objectDeletionBackoff(od.Attempts)
+			d.log.Error(err, "Failed to delete the object", "path", od.ObjectStorePath, "attempts", od.Attempts+1, "backoff", backoff)
+			if err := d.store.UpdateObjectDeletionFailure(od, err.Error(), now.Add(backoff)); err != nil {
+				return err
+			}
+			continue
+		}
+		d.log.Info("Deleted the object", "path", od.ObjectStorePath)
+		if err := d.store.DeleteObjectDeletion(od.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// objectDeletionBackoff returns the backoff duration after the given number of failed attempts.
+func objectDeletionBackoff(attempts int) time.Duration {
+	b := objectDeletionInitialBackoff
+	for i := 0; i < attempts; i++ {
+		b *= 2
+		if b >= objectDeletionMaxBackoff {
+			return objectDeletionMaxBackoff
+		}
+	}
+	return b
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/llmariner/api-usage/pkg/sender"
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteFileAndObject(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	objectStore.objs["pathPrefix/f0"] = []byte("hello")
+	for _, spec := range []store.FileSpec{
+		{
+			FileID:          "f0",
+			ObjectStorePath: "pathPrefix/f0",
+		},
+		{
+			FileID:          "f1",
+			ObjectStorePath: "s3://bucket/f1",
+		},
+	} {
+		spec.TenantID = defaultTenantID
+		spec.ProjectID = defaultProjectID
+		_, err := st.CreateFile(spec)
+		assert.NoError(t, err)
+	}
+
+	for _, id := range []string{"f0", "f1"} {
+		_, err := srv.DeleteFile(ctx, &v1.DeleteFileRequest{Id: id})
+		assert.NoError(t, err)
+	}
+
+	// Only the object uploaded by file-manager is enqueued.
+	now := time.Now()
+	ds, err := st.ListDueObjectDeletions(now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 1)
+	assert.Equal(t, "pathPrefix/f0", ds[0].ObjectStorePath)
+
+	d := NewObjectDeleter(st, objectStore, testr.New(t))
+
+	// The deletion is retried after the failure.
+	objectStore.deleteErr = errors.New("unavailable")
+	err = d.processDueDeletions(context.Background(), now)
+	assert.NoError(t, err)
+	ds, err = st.ListDueObjectDeletions(now, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, ds)
+	assert.Contains(t, objectStore.objs, "pathPrefix/f0")
+
+	objectStore.deleteErr = nil
+	now = now.Add(objectDeletionInitialBackoff)
+	err = d.processDueDeletions(context.Background(), now)
+	assert.NoError(t, err)
+	assert.NotContains(t, objectStore.objs, "pathPrefix/f0")
+	ds, err = st.ListDueObjectDeletions(now.Add(objectDeletionMaxBackoff), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, ds)
+}
+
+func TestObjectDeletionBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, objectDeletionBackoff(0))
+	assert.Equal(t, time.Minute, objectDeletionBackoff(1))
+	assert.Equal(t, 4*time.Minute, objectDeletionBackoff(3))
+	assert.Equal(t, time.Hour, objectDeletionBackoff(10))
+}
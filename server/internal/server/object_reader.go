@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/llmariner/file-manager/server/internal/objectstore"
+)
+
+// objectReader is an io.ReadSeeker that reads an object in the object store. Seeking does not
+// issue any request; the next read starts a ranged download from the current offset.
+type objectReader struct {
+	ctx         context.Context
+	objectStore ObjectStore
+	key         string
+	size        int64
+	enc         objectstore.Encryption
+
+	offset int64
+	body   io.ReadCloser
+}
+
+func newObjectReader(ctx context.Context, objectStore ObjectStore, key string, size int64, enc objectstore.Encryption) *objectReader {
+	return &objectReader{
+		ctx:         ctx,
+		objectStore: objectStore,
+		key:         key,
+		size:        size,
+		enc:         enc,
+	}
+}
+
+// Read implements io.Reader.
+func (r *objectReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	if err == io.EOF && r.offset < r.size {
+		return n, io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// open starts a download from the current offset unless there is nothing to read.
+func (r *objectReader) open() error {
+	if r.body != nil || r.offset >= r.size {
+		return nil
+	}
+	body, err := r.objectStore.Download(r.ctx, r.key, r.offset, r.size-r.offset, r.enc)
+	if err != nil {
+		return fmt.Errorf("download: %w", err)
+	}
+	r.body = body
+	return nil
+}
+
+// Seek implements io.Seeker.
+func (r *objectReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = r.offset + offset
+	case io.SeekEnd:
+		abs = r.size + offset
+	default:
+		return 0, fmt.Errorf("invalid whence: %d", whence)
+	}
+	if abs < 0 {
+		return 0, fmt.Errorf("negative position: %d", abs)
+	}
+	if abs == r.offset {
+		return abs, nil
+	}
+	if err := r.Close(); err != nil {
+		return 0, err
+	}
+	r.offset = abs
+	return abs, nil
+}
+
+// Close closes the body of the ongoing download if any.
+func (r *objectReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
+
+// statusRecordingWriter is an http.ResponseWriter that records the status code.
+type statusRecordingWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (w *statusRecordingWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/llmariner/file-manager/server/internal/objectstore"
+)
+
+// presignExpiresAt is the expiry of the URLs presigned by memoryObjectStore.
+var presignExpiresAt = time.Unix(4100000000, 0)
+
+// memoryObjectStore is an in-memory implementation of ObjectStore for tests. Objects in other buckets
+// are stored with "s3://<bucket>/<key>" keys.
+type memoryObjectStore struct {
+	objs map[string][]byte
+	// lastModified is the last modified time of objects. Objects not in the map are
+	// treated as modified at the zero time.
+	lastModified map[string]time.Time
+	// mpus is the parts of ongoing multipart uploads keyed by upload IDs and part numbers.
+	mpus map[string]map[int32][]byte
+
+	// encryptionModes is the encryption modes of tenants keyed by tenant IDs.
+	encryptionModes map[string]string
+	// objEncryptions is the encryption of objects keyed by their keys.
+	objEncryptions map[string]objectstore.Encryption
+
+	// downloads is the number of calls of Download.
+	downloads int
+
+	// deleteErr and statErr are returned by Delete and Stat if set.
+	deleteErr error
+	statErr   error
+}
+
+func newMemoryObjectStore() *memoryObjectStore {
+	return &memoryObjectStore{
+		objs:         map[string][]byte{},
+		lastModified: map[string]time.Time{},
+	}
+}
+
+func (c *memoryObjectStore) EncryptionMode(tenantID string) string {
+	return c.encryptionModes[tenantID]
+}
+
+func (c *memoryObjectStore) Upload(ctx context.Context, r io.Reader, key string, enc objectstore.Encryption) (string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	c.objs[key] = b
+	c.setEncryption(key, enc)
+	return fmt.Sprintf("%q", fmt.Sprintf("%x", md5.Sum(b))), nil
+}
+
+func (c *memoryObjectStore) Download(ctx context.Context, key string, offset, length int64, enc objectstore.Encryption) (io.ReadCloser, error) {
+	c.downloads++
+	b, ok := c.objs[key]
+	if !ok {
+		return nil, fmt.Errorf("object %q: %w", key, fs.ErrNotExist)
+	}
+	if err := c.checkEncryption(key, enc); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(b[offset : offset+length])), nil
+}
+
+// DownloadBucketObject looks up objects in other buckets with "s3://<bucket>/<key>" keys.
+func (c *memoryObjectStore) DownloadBucketObject(ctx context.Context, bucket, key string, offset, length int64) (io.ReadCloser, error) {
+	return c.Download(ctx, fmt.Sprintf("s3://%s/%s", bucket, key), offset, length, objectstore.Encryption{})
+}
+
+func (c *memoryObjectStore) Copy(ctx context.Context, srcKey, dstKey string, enc objectstore.Encryption) error {
+	b, ok := c.objs[srcKey]
+	if !ok {
+		return fmt.Errorf("object %q: %w", srcKey, fs.ErrNotExist)
+	}
+	if err := c.checkEncryption(srcKey, enc); err != nil {
+		return err
+	}
+	c.objs[dstKey] = b
+	c.setEncryption(dstKey, enc)
+	return nil
+}
+
+func (c *memoryObjectStore) Delete(ctx context.Context, key string) error {
+	if c.deleteErr != nil {
+		return c.deleteErr
+	}
+	delete(c.objs, key)
+	return nil
+}
+
+func (c *memoryObjectStore) List(ctx context.Context, prefix string, f func(key string, lastModified time.Time) error) error {
+	var keys []string
+	for k := range c.objs {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := f(k, c.lastModified[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *memoryObjectStore) Stat(ctx context.Context, key string, enc objectstore.Encryption) (int64, string, time.Time, error) {
+	if c.statErr != nil {
+		return 0, "", time.Time{}, c.statErr
+	}
+	b, ok := c.objs[key]
+	if !ok {
+		return 0, "", time.Time{}, fmt.Errorf("object %q: %w", key, fs.ErrNotExist)
+	}
+	if err := c.checkEncryption(key, enc); err != nil {
+		return 0, "", time.Time{}, err
+	}
+	return int64(len(b)), fmt.Sprintf("%q", fmt.Sprintf("%x", md5.Sum(b))), c.lastModified[key], nil
+}
+
+// StatBucketObject looks up objects in other buckets with "s3://<bucket>/<key>" keys.
+func (c *memoryObjectStore) StatBucketObject(ctx context.Context, bucket, key string) (int64, string, time.Time, error) {
+	return c.Stat(ctx, fmt.Sprintf("s3://%s/%s", bucket, key), objectstore.Encryption{})
+}
+
+func (c *memoryObjectStore) PresignUpload(ctx context.Context, key string, enc objectstore.Encryption) (string, time.Time, error) {
+	return "https://s3.example.com/" + key + "?X-Amz-Signature=put", presignExpiresAt, nil
+}
+
+func (c *memoryObjectStore) PresignDownload(ctx context.Context, key, filename string, enc objectstore.Encryption) (string, time.Time, error) {
+	return "https://s3.example.com/" + key + "?X-Amz-Signature=get", presignExpiresAt, nil
+}
+
+func (c *memoryObjectStore) CreateMultipartUpload(ctx context.Context, key string, enc objectstore.Encryption) (string, error) {
+	if c.mpus == nil {
+		c.mpus = map[string]map[int32][]byte{}
+	}
+	id := fmt.Sprintf("mpu%d", len(c.mpus))
+	c.mpus[id] = map[int32][]byte{}
+	return id, nil
+}
+
+func (c *memoryObjectStore) UploadPartCopy(ctx context.Context, key, uploadID string, partNumber int32, srcKey string, enc objectstore.Encryption) (string, error) {
+	parts, ok := c.mpus[uploadID]
+	if !ok {
+		return "", fmt.Errorf("upload %q not found", uploadID)
+	}
+	b, ok := c.objs[srcKey]
+	if !ok {
+		return "", fmt.Errorf("object %q: %w", srcKey, fs.ErrNotExist)
+	}
+	if err := c.checkEncryption(srcKey, enc); err != nil {
+		return "", err
+	}
+	parts[partNumber] = b
+	return fmt.Sprintf("%q", fmt.Sprintf("%x", md5.Sum(b))), nil
+}
+
+func (c *memoryObjectStore) CompleteMultipartUpload(ctx context.Context, key, uploadID string, partETags map[int32]string, enc objectstore.Encryption) (string, error) {
+	parts, ok := c.mpus[uploadID]
+	if !ok {
+		return "", fmt.Errorf("upload %q not found", uploadID)
+	}
+	var ns []int
+	for n := range partETags {
+		ns = append(ns, int(n))
+	}
+	sort.Ints(ns)
+	var b []byte
+	for _, n := range ns {
+		b = append(b, parts[int32(n)]...)
+	}
+	c.objs[key] = b
+	c.setEncryption(key, enc)
+	delete(c.mpus, uploadID)
+	return fmt.Sprintf("%q", fmt.Sprintf("%x-%d", md5.Sum(b), len(ns))), nil
+}
+
+func (c *memoryObjectStore) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	delete(c.mpus, uploadID)
+	return nil
+}
+
+func (c *memoryObjectStore) setEncryption(key string, enc objectstore.Encryption) {
+	if c.objEncryptions == nil {
+		c.objEncryptions = map[string]objectstore.Encryption{}
+	}
+	c.objEncryptions[key] = enc
+}
+
+// checkEncryption returns an error if the object is encrypted with SSE-C and not read with the same key
+// as S3 does. Objects encrypted with the other modes can be read without keys.
+func (c *memoryObjectStore) checkEncryption(key string, enc objectstore.Encryption) error {
+	if e := c.objEncryptions[key]; e.Mode == objectstore.EncryptionModeSSEC && e != enc {
+		return fmt.Errorf("object %q is encrypted with a customer key", key)
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"regexp"
+	"time"
+
+	"github.com/llmariner/common/pkg/id"
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/objectstore"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/llmariner/rbac-manager/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// fileIDPattern matches file IDs generated by the server. Used to reject file IDs
+// that could point to an arbitrary object under the path prefix.
+var fileIDPattern = regexp.MustCompile(`^file-[a-zA-Z0-9_-]+$`)
+
+// presignedUploadGracePeriod is the duration after the expiry of a presigned upload URL during which
+// the upload can be finalized. This allows the uploads started just before the expiry to be finalized.
+const presignedUploadGracePeriod = time.Hour
+
+// CreateFileUploadURL returns a presigned URL to upload a file directly to the object storage.
+func (s *S) CreateFileUploadURL(
+	ctx context.Context,
+	req *v1.CreateFileUploadURLRequest,
+) (*v1.CreateFileUploadURLResponse, error) {
+	userInfo, ok := auth.ExtractUserInfoFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to extract user info from context")
+	}
+
+	if !s.enableFileUpload {
+		return nil, status.Error(codes.PermissionDenied, fileUploadDisabledMsg)
+	}
+
+	if req.Filename == "" {
+		return nil, status.Error(codes.InvalidArgument, "filename is required")
+	}
+	rule, err := validateUploadPurpose(req.Purpose)
+	if err != nil {
+		return nil, err
+	}
+	if err := rule.validateFilename(req.Filename); err != nil {
+		return nil, err
+	}
+
+	fileID, err := id.GenerateID("file-", 24)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate file id: %s", err)
+	}
+	enc := s.newObjectEncryption(userInfo.TenantID)
+	url, expiresAt, err := s.objectStore.PresignUpload(ctx, s.filePath(fileID), enc)
+	if err != nil {
+		return nil, presignError(err)
+	}
+	// Record the upload so that only the objects uploaded with the URLs issued here can be finalized.
+	if err := s.store.CreatePresignedUpload(&store.PresignedUpload{
+		FileID:         fileID,
+		TenantID:       userInfo.TenantID,
+		OrganizationID: userInfo.OrganizationID,
+		ProjectID:      userInfo.ProjectID,
+		Filename:       req.Filename,
+		Purpose:        req.Purpose,
+		EncryptionMode: enc.Mode,
+		ExpiresAt:      expiresAt.Add(presignedUploadGracePeriod),
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "create presigned upload: %s", err)
+	}
+	return &v1.CreateFileUploadURLResponse{
+		FileId:    fileID,
+		Url:       url,
+		ExpiresAt: expiresAt.UTC().Unix(),
+	}, nil
+}
+
+// FinalizeFileUpload creates a file from the object uploaded with a presigned URL. The filename and the purpose
+// must be the same as the ones given when the URL is issued.
+func (s *S) FinalizeFileUpload(
+	ctx context.Context,
+	req *v1.FinalizeFileUploadRequest,
+) (*v1.File, error) {
+	userInfo, ok := auth.ExtractUserInfoFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to extract user info from context")
+	}
+
+	if !s.enableFileUpload {
+		return nil, status.Error(codes.PermissionDenied, fileUploadDisabledMsg)
+	}
+
+	if req.FileId == "" {
+		return nil, status.Error(codes.InvalidArgument, "file_id is required")
+	}
+	if !fileIDPattern.MatchString(req.FileId) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid file_id: %q", req.FileId)
+	}
+	if req.Filename == "" {
+		return nil, status.Error(codes.InvalidArgument, "filename is required")
+	}
+	rule, err := validateUploadPurpose(req.Purpose)
+	if err != nil {
+		return nil, err
+	}
+	if err := rule.validateFilename(req.Filename); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.GetFileByFileID(req.FileId); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "file %q already exists", req.FileId)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.Internal, "get file: %s", err)
+	}
+
+	u, err := s.store.GetPresignedUploadByFileIDAndProjectID(req.FileId, userInfo.ProjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "presigned upload of file %q not found", req.FileId)
+		}
+		return nil, status.Errorf(codes.Internal, "get presigned upload: %s", err)
+	}
+	if u.TenantID != userInfo.TenantID {
+		return nil, status.Errorf(codes.NotFound, "presigned upload of file %q not found", req.FileId)
+	}
+	if !time.Now().Before(u.ExpiresAt) {
+		return nil, status.Errorf(codes.FailedPrecondition, "presigned upload of file %q has expired", req.FileId)
+	}
+	if req.Filename != u.Filename {
+		return nil, status.Errorf(codes.InvalidArgument, "filename %q does not match %q given when the upload URL was created", req.Filename, u.Filename)
+	}
+	if req.Purpose != u.Purpose {
+		return nil, status.Errorf(codes.InvalidArgument, "purpose %q does not match %q given when the upload URL was created", req.Purpose, u.Purpose)
+	}
+
+	// Use the size and the ETag of the uploaded object instead of trusting the client.
+	path := s.filePath(req.FileId)
+	enc := objectstore.Encryption{
+		TenantID: u.TenantID,
+		Mode:     u.EncryptionMode,
+	}
+	size, etag, _, err := s.objectStore.Stat(ctx, path, enc)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, status.Errorf(codes.NotFound, "object for file %q not found. upload the file content first", req.FileId)
+		}
+		return nil, status.Errorf(codes.Internal, "stat object: %s", err)
+	}
+	if err := rule.validateSize(size); err != nil {
+		// Keep the object so that the client can upload the content again with the same URL. The object is
+		// deleted by the garbage collector if no file is created.
+		return nil, err
+	}
+	// Validate the content in the same way as CreateFile as the content is not streamed through the server.
+	cv, sums, err := s.scanUploadedObject(ctx, rule, path, size, enc)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "read uploaded object: %s", err)
+	}
+	var (
+		validationStatus store.ValidationStatus
+		lines            int64
+	)
+	if cv != nil {
+		if !cv.Valid() {
+			return nil, contentValidationError(req.Purpose, cv)
+		}
+		validationStatus = store.ValidationStatusValid
+		lines = int64(cv.Lines())
+	}
+
+	spec := store.FileSpec{
+		FileID:         req.FileId,
+		TenantID:       userInfo.TenantID,
+		OrganizationID: userInfo.OrganizationID,
+		ProjectID:      userInfo.ProjectID,
+
+		Filename: req.Filename,
+		Purpose:  req.Purpose,
+		Bytes:    size,
+
+		ObjectStorePath: path,
+		ETag:            etag,
+
+		ValidationStatus: validationStatus,
+		Lines:            lines,
+
+		SHA256: sums.sha256Hex(),
+		MD5:    sums.md5Hex(),
+
+		EncryptionMode: enc.Mode,
+	}
+	var f *store.File
+	if err := s.store.Transaction(func(tx *gorm.DB) error {
+		if err := store.DeletePresignedUploadInTransaction(tx, u.FileID); err != nil {
+			return err
+		}
+		if err := s.checkQuotaInTransaction(tx, spec.TenantID, spec.ProjectID, spec.Bytes); err != nil {
+			return err
+		}
+		var err error
+		f, err = store.CreateFileInTransaction(tx, spec)
+		return err
+	}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "presigned upload of file %q has been finalized concurrently", req.FileId)
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "finalize file upload: %s", err)
+	}
+	return toFileProto(f), nil
+}
+
+// GetFileDownloadURL returns a presigned URL to download a file directly from the object storage.
+func (s *S) GetFileDownloadURL(
+	ctx context.Context,
+	req *v1.GetFileDownloadURLRequest,
+) (*v1.GetFileDownloadURLResponse, error) {
+	userInfo, ok := auth.ExtractUserInfoFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to extract user info from context")
+	}
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	f, err := s.store.GetFile(req.Id, userInfo.ProjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "file %q not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "get file: %s", err)
+	}
+	if isExternalObjectPath(f.ObjectStorePath) {
+		return nil, status.Errorf(codes.FailedPrecondition, "file %q is not stored in the file manager's bucket", req.Id)
+	}
+
+	url, expiresAt, err := s.objectStore.PresignDownload(ctx, f.ObjectStorePath, f.Filename, fileEncryption(f))
+	if err != nil {
+		return nil, presignError(err)
+	}
+	return &v1.GetFileDownloadURLResponse{
+		Url:       url,
+		ExpiresAt: expiresAt.UTC().Unix(),
+	}, nil
+}
+
+func presignError(err error) error {
+	if errors.Is(err, errors.ErrUnsupported) {
+		return status.Error(codes.Unimplemented, "presigned URLs are not supported by the object store")
+	}
+	return status.Errorf(codes.Internal, "presign URL: %s", err)
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/llmariner/api-usage/pkg/sender"
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestPresignedUploadAndDownload(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	_, err := srv.CreateFileUploadURL(ctx, &v1.CreateFileUploadURLRequest{
+		Filename: "test.jsonl",
+		Purpose:  "invalid",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := srv.CreateFileUploadURL(ctx, &v1.CreateFileUploadURLRequest{
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://s3.example.com/pathPrefix/"+resp.FileId+"?X-Amz-Signature=put", resp.Url)
+	assert.Equal(t, presignExpiresAt.Unix(), resp.ExpiresAt)
+
+	finalizeReq := &v1.FinalizeFileUploadRequest{
+		FileId:   resp.FileId,
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+	}
+
+	// No URL has been issued for the file.
+	objectStore.objs["pathPrefix/file-unknown"] = []byte(fineTuneLine)
+	_, err = srv.FinalizeFileUpload(ctx, &v1.FinalizeFileUploadRequest{
+		FileId:   "file-unknown",
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// The filename and the purpose must match the ones given when the URL was issued.
+	_, err = srv.FinalizeFileUpload(ctx, &v1.FinalizeFileUploadRequest{
+		FileId:   resp.FileId,
+		Filename: "other.jsonl",
+		Purpose:  purposeFineTune,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = srv.FinalizeFileUpload(ctx, &v1.FinalizeFileUploadRequest{
+		FileId:   resp.FileId,
+		Filename: "test.jsonl",
+		Purpose:  purposeBatch,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The object has not been uploaded yet.
+	_, err = srv.FinalizeFileUpload(ctx, finalizeReq)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Simulate the upload with the presigned URL. The content is not valid for the purpose.
+	objectStore.objs["pathPrefix/"+resp.FileId] = []byte("hello")
+	_, err = srv.FinalizeFileUpload(ctx, finalizeReq)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "invalid fine-tune file: line 1: invalid JSON")
+
+	objectStore.objs["pathPrefix/"+resp.FileId] = []byte(fineTuneLine)
+	f, err := srv.FinalizeFileUpload(ctx, finalizeReq)
+	assert.NoError(t, err)
+	assert.Equal(t, resp.FileId, f.Id)
+	assert.Equal(t, int64(len(fineTuneLine)), f.Bytes)
+	assert.Equal(t, "test.jsonl", f.Filename)
+	assert.Equal(t, "pathPrefix/"+resp.FileId, f.ObjectStorePath)
+	sf, err := st.GetFileByFileID(f.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, store.ValidationStatusValid, sf.ValidationStatus)
+	assert.Equal(t, int64(1), sf.Lines)
+
+	_, err = srv.FinalizeFileUpload(ctx, finalizeReq)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = st.GetPresignedUploadByFileIDAndProjectID(resp.FileId, defaultProjectID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	_, err = srv.FinalizeFileUpload(ctx, &v1.FinalizeFileUploadRequest{
+		FileId:   "file-abc/../other",
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	dresp, err := srv.GetFileDownloadURL(ctx, &v1.GetFileDownloadURLRequest{Id: f.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://s3.example.com/pathPrefix/"+f.Id+"?X-Amz-Signature=get", dresp.Url)
+
+	_, err = srv.GetFileDownloadURL(ctx, &v1.GetFileDownloadURLRequest{Id: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	objectStore.objs["s3://bucket/test.jsonl"] = []byte("external")
+	ef, err := srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/test.jsonl",
+		Purpose:    purposeFineTune,
+	})
+	assert.NoError(t, err)
+	_, err = srv.GetFileDownloadURL(ctx, &v1.GetFileDownloadURLRequest{Id: ef.Id})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestExpirePresignedUploads(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	resp, err := srv.CreateFileUploadURL(ctx, &v1.CreateFileUploadURLRequest{
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+	})
+	assert.NoError(t, err)
+	objectStore.objs["pathPrefix/"+resp.FileId] = []byte(fineTuneLine)
+
+	e := NewUploadExpirer(st, objectStore, testr.New(t))
+	err = e.expireUploads(context.Background(), presignExpiresAt)
+	assert.NoError(t, err)
+	_, err = st.GetPresignedUploadByFileIDAndProjectID(resp.FileId, defaultProjectID)
+	assert.NoError(t, err)
+
+	err = e.expireUploads(context.Background(), presignExpiresAt.Add(presignedUploadGracePeriod))
+	assert.NoError(t, err)
+	_, err = srv.FinalizeFileUpload(ctx, &v1.FinalizeFileUploadRequest{
+		FileId:   resp.FileId,
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestPresignedURLUnsupported(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	srv := New(st, &NoopObjectStore{}, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	_, err := srv.CreateFileUploadURL(ctx, &v1.CreateFileUploadURLRequest{
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+	})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/llmariner/file-manager/server/internal/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Purposes defined in the OpenAI API spec (https://platform.openai.com/docs/api-reference/files/object).
+const (
+	purposeFineTune         = "fine-tune"
+	purposeFineTuneResults  = "fine-tune-results"
+	purposeAssistants       = "assistants"
+	purposeAssistantsOutput = "assistants_output"
+	purposeBatch            = "batch"
+	purposeBatchOutput      = "batch_output"
+	purposeVision           = "vision"
+	purposeUserData         = "user_data"
+	purposeEvals            = "evals"
+)
+
+// maxValidationErrors is the maximum number of errors reported for the content of a file.
+const maxValidationErrors = 10
+
+// purposeRule is a set of rules applied to files with a purpose.
+type purposeRule struct {
+	// extensions is the list of allowed extensions of files uploaded by clients. Any extension is allowed if empty.
+	// Objects registered with CreateFileFromObjectPath are not subject to this.
+	extensions []string
+	// maxBytes is the maximum size of a file. There is no limit if 0.
+	maxBytes int64
+	// newLineValidator returns a validator of the content of an uploaded file. The content is not
+	// validated if nil.
+	newLineValidator func() validation.LineValidator
+	// validateRegisteredObjects is true if the content of an object registered with CreateFileFromObjectPath
+	// is validated. This is enabled only for purposes whose files are small enough to read in the control plane.
+	validateRegisteredObjects bool
+	// output is true if files with the purpose are generated by jobs. Such files cannot be uploaded by users.
+	output bool
+}
+
+// purposeRules is the registry of the supported purposes.
+//
+// No size limit is set for "fine-tune" and "assistants" as they have been accepted without limit.
+var purposeRules = map[string]*purposeRule{
+	purposeFineTune: {
+		extensions: []string{".jsonl"},
+		newLineValidator: func() validation.LineValidator {
+			return validation.NewFineTuneValidator()
+		},
+	},
+	purposeFineTuneResults: {
+		output: true,
+	},
+	purposeAssistants: {},
+	purposeAssistantsOutput: {
+		output: true,
+	},
+	purposeBatch: {
+		extensions: []string{".jsonl"},
+		maxBytes:   200 << 20,
+		newLineValidator: func() validation.LineValidator {
+			return validation.NewBatchValidator()
+		},
+		validateRegisteredObjects: true,
+	},
+	purposeBatchOutput: {
+		output: true,
+	},
+	purposeVision: {
+		extensions: []string{".png", ".jpeg", ".jpg", ".gif", ".webp"},
+		maxBytes:   20 << 20,
+	},
+	purposeUserData: {},
+	purposeEvals: {
+		extensions: []string{".jsonl"},
+		newLineValidator: func() validation.LineValidator {
+			return validation.NewJSONObjectValidator()
+		},
+	},
+}
+
+// anyPurposeRule is the rule applied to a file uploaded before its purpose is given. It only limits the size
+// of the file to the largest limit of the purposes whose files can be uploaded.
+var anyPurposeRule = &purposeRule{maxBytes: largestUploadLimit()}
+
+// largestUploadLimit returns the largest size limit of the purposes whose files can be uploaded. It returns 0
+// if a purpose has no limit.
+func largestUploadLimit() int64 {
+	var limit int64
+	for _, r := range purposeRules {
+		if r.output {
+			continue
+		}
+		if r.maxBytes == 0 {
+			return 0
+		}
+		if r.maxBytes > limit {
+			limit = r.maxBytes
+		}
+	}
+	return limit
+}
+
+// validatePurpose validates that the purpose is one of the supported purposes.
+func validatePurpose(p string) error {
+	_, err := lookupPurposeRule(p)
+	return err
+}
+
+// validateUploadPurpose validates that files with the purpose can be uploaded by users and returns its rule.
+func validateUploadPurpose(p string) (*purposeRule, error) {
+	r, err := lookupPurposeRule(p)
+	if err != nil {
+		return nil, err
+	}
+	if r.output {
+		return nil, status.Errorf(codes.InvalidArgument, "files with purpose %q cannot be uploaded", p)
+	}
+	return r, nil
+}
+
+func lookupPurposeRule(p string) (*purposeRule, error) {
+	r, ok := purposeRules[p]
+	if !ok {
+		var ps []string
+		for p := range purposeRules {
+			ps = append(ps, p)
+		}
+		sort.Strings(ps)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid purpose: %q. must be one of %s", p, strings.Join(ps, ", "))
+	}
+	return r, nil
+}
+
+// validateFilename validates the extension of the filename.
+func (r *purposeRule) validateFilename(filename string) error {
+	if len(r.extensions) == 0 {
+		return nil
+	}
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, e := range r.extensions {
+		if ext == e {
+			return nil
+		}
+	}
+	return status.Errorf(codes.InvalidArgument, "invalid file extension of %q. must be one of %s", filename, strings.Join(r.extensions, ", "))
+}
+
+// newContentValidator returns a validator of the content of a file. It returns nil if the content is not validated.
+func (r *purposeRule) newContentValidator() *validation.JSONLValidator {
+	if r.newLineValidator == nil {
+		return nil
+	}
+	return validation.NewJSONLValidator(r.newLineValidator(), maxValidationErrors)
+}
+
+// validateSize validates the size of a file.
+func (r *purposeRule) validateSize(bytes int64) error {
+	if r.maxBytes > 0 && bytes > r.maxBytes {
+		return status.Errorf(codes.InvalidArgument, "file size must not exceed %d bytes", r.maxBytes)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/llmariner/rbac-manager/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// GetUsage returns the consumption of the storage quotas of the project and the tenant.
+func (s *S) GetUsage(
+	ctx context.Context,
+	req *v1.GetUsageRequest,
+) (*v1.Usage, error) {
+	userInfo, ok := auth.ExtractUserInfoFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to extract user info from context")
+	}
+
+	var usage v1.Usage
+	if err := s.store.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if usage.Project, err = s.quotaUsageInTransaction(tx, store.QuotaScopeProject, userInfo.ProjectID); err != nil {
+			return err
+		}
+		if usage.Tenant, err = s.quotaUsageInTransaction(tx, store.QuotaScopeTenant, userInfo.TenantID); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "get usage: %s", err)
+	}
+	return &usage, nil
+}
+
+// checkQuota checks that a file of the given size can be created in the project and the tenant.
+// It is used to reject a file before its content is uploaded. The size is 0 if it is not known
+// before the upload. It does not reserve the quota, and the quota is checked again when the file is created.
+func (s *S) checkQuota(tenantID, projectID string, bytes int64) error {
+	return s.store.Transaction(func(tx *gorm.DB) error {
+		return s.checkQuotaLimitsInTransaction(tx, tenantID, projectID, bytes, false)
+	})
+}
+
+// checkQuotaInTransaction checks that a file of the given size can be created in the project and the tenant.
+// It returns a ResourceExhausted error if a quota is exceeded.
+//
+// It must be called in the same transaction as the creation of the file. The quotas of the project and
+// the tenant are locked until the end of the transaction so that concurrent creations do not exceed them.
+func (s *S) checkQuotaInTransaction(tx *gorm.DB, tenantID, projectID string, bytes int64) error {
+	return s.checkQuotaLimitsInTransaction(tx, tenantID, projectID, bytes, true)
+}
+
+func (s *S) checkQuotaLimitsInTransaction(tx *gorm.DB, tenantID, projectID string, bytes int64, lock bool) error {
+	// Lock the project before the tenant in all transactions to avoid deadlocks.
+	for _, q := range []struct {
+		scope store.QuotaScope
+		id    string
+	}{
+		{scope: store.QuotaScopeProject, id: projectID},
+		{scope: store.QuotaScopeTenant, id: tenantID},
+	} {
+		l, err := s.quotaLimitsInTransaction(tx, q.scope, q.id)
+		if err != nil {
+			return status.Errorf(codes.Internal, "get quota limits: %s", err)
+		}
+		if l.MaxBytes == 0 && l.MaxFiles == 0 {
+			// Do not serialize the creations of files without limits.
+			continue
+		}
+		if lock {
+			if err := store.LockQuotaInTransaction(tx, q.scope, q.id); err != nil {
+				return status.Errorf(codes.Internal, "lock quota: %s", err)
+			}
+		}
+		u, err := store.GetFileUsageInTransaction(tx, q.scope, q.id)
+		if err != nil {
+			return status.Errorf(codes.Internal, "get usage: %s", err)
+		}
+		if l.MaxFiles > 0 && u.Files+1 > l.MaxFiles {
+			return status.Errorf(codes.ResourceExhausted, "%s quota exceeded: the number of files must not exceed %d", q.scope, l.MaxFiles)
+		}
+		if l.MaxBytes > 0 && u.Bytes+bytes > l.MaxBytes {
+			return status.Errorf(codes.ResourceExhausted, "%s quota exceeded: the total size of files must not exceed %d bytes (used: %d bytes, requested: %d bytes)", q.scope, l.MaxBytes, u.Bytes, bytes)
+		}
+	}
+	return nil
+}
+
+// createFileWithQuota creates a file after checking the quotas in a transaction.
+func (s *S) createFileWithQuota(spec store.FileSpec) (*store.File, error) {
+	var f *store.File
+	if err := s.store.Transaction(func(tx *gorm.DB) error {
+		if err := s.checkQuotaInTransaction(tx, spec.TenantID, spec.ProjectID, spec.Bytes); err != nil {
+			return err
+		}
+		var err error
+		f, err = store.CreateFileInTransaction(tx, spec)
+		return err
+	}); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "create file: %s", err)
+	}
+	return f, nil
+}
+
+func (s *S) quotaUsageInTransaction(tx *gorm.DB, scope store.QuotaScope, scopeID string) (*v1.QuotaUsage, error) {
+	l, err := s.quotaLimitsInTransaction(tx, scope, scopeID)
+	if err != nil {
+		return nil, err
+	}
+	u, err := store.GetFileUsageInTransaction(tx, scope, scopeID)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.QuotaUsage{
+		Bytes:    u.Bytes,
+		Files:    u.Files,
+		MaxBytes: l.MaxBytes,
+		MaxFiles: l.MaxFiles,
+	}, nil
+}
+
+// quotaLimitsInTransaction returns the limits of a project or a tenant. The defaults are overridden
+// by the values stored in the database.
+func (s *S) quotaLimitsInTransaction(tx *gorm.DB, scope store.QuotaScope, scopeID string) (config.QuotaLimits, error) {
+	l := s.quota.Project
+	if scope == store.QuotaScopeTenant {
+		l = s.quota.Tenant
+	}
+	o, err := store.GetQuotaOverrideInTransaction(tx, scope, scopeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return l, nil
+		}
+		return config.QuotaLimits{}, err
+	}
+	if o.MaxBytes != nil {
+		l.MaxBytes = *o.MaxBytes
+	}
+	if o.MaxFiles != nil {
+		l.MaxFiles = *o.MaxFiles
+	}
+	return l, nil
+}
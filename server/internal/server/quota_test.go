@@ -0,0 +1,105 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/llmariner/api-usage/pkg/sender"
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQuota(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := &memoryObjectStore{objs: map[string][]byte{
+		"s3://bucket/f0.jsonl": []byte(strings.Repeat("a", 60)),
+		"s3://bucket/f1.jsonl": []byte(strings.Repeat("a", 50)),
+		"s3://bucket/f2.jsonl": []byte(strings.Repeat("a", 1)),
+	}}
+	quota := config.QuotaConfig{
+		Project: config.QuotaLimits{MaxFiles: 2},
+		Tenant:  config.QuotaLimits{MaxBytes: 100},
+	}
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, quota, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	createFile := func(path string) error {
+		_, err := srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+			ObjectPath: path,
+			Purpose:    purposeAssistants,
+		})
+		return err
+	}
+
+	err := createFile("s3://bucket/f0.jsonl")
+	assert.NoError(t, err)
+
+	// The total size exceeds the quota of the tenant.
+	err = createFile("s3://bucket/f1.jsonl")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = srv.CreateUpload(ctx, &v1.CreateUploadRequest{
+		Filename: "f1.jsonl",
+		Purpose:  purposeAssistants,
+		Bytes:    50,
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	usage, err := srv.GetUsage(ctx, &v1.GetUsageRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(60), usage.Project.Bytes)
+	assert.Equal(t, int64(1), usage.Project.Files)
+	assert.Equal(t, int64(2), usage.Project.MaxFiles)
+	assert.Equal(t, int64(0), usage.Project.MaxBytes)
+	assert.Equal(t, int64(100), usage.Tenant.MaxBytes)
+
+	// Remove the limit of the tenant.
+	maxBytes := int64(0)
+	err = st.UpsertQuotaOverride(&store.QuotaOverride{
+		Scope:    store.QuotaScopeTenant,
+		ScopeID:  defaultTenantID,
+		MaxBytes: &maxBytes,
+	})
+	assert.NoError(t, err)
+
+	err = createFile("s3://bucket/f1.jsonl")
+	assert.NoError(t, err)
+
+	// The number of files exceeds the quota of the project.
+	err = createFile("s3://bucket/f2.jsonl")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	err = w.WriteField("purpose", purposeAssistants)
+	assert.NoError(t, err)
+	fw, err := w.CreateFormFile("file", "f2.jsonl")
+	assert.NoError(t, err)
+	_, err = fw.Write([]byte("a"))
+	assert.NoError(t, err)
+	err = w.Close()
+	assert.NoError(t, err)
+	req, err := http.NewRequest("POST", "v1/files", &b)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	rr := httptest.NewRecorder()
+	srv.CreateFile(rr, req, nil)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+
+	usage, err = srv.GetUsage(ctx, &v1.GetUsageRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(110), usage.Project.Bytes)
+	assert.Equal(t, int64(2), usage.Project.Files)
+	assert.Equal(t, int64(0), usage.Tenant.MaxBytes)
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/go-logr/logr"
+	"github.com/llmariner/file-manager/server/internal/store"
+)
+
+// scrubBatchSize is the number of files read from the database at once.
+const scrubBatchSize = 100
+
+// NewScrubber creates a new scrubber.
+func NewScrubber(st *store.S, objectStore ObjectStore, dryRun bool, log logr.Logger) *Scrubber {
+	return &Scrubber{
+		store:       st,
+		objectStore: objectStore,
+		dryRun:      dryRun,
+		log:         log.WithName("scrubber"),
+	}
+}
+
+// Scrubber re-reads the objects of files uploaded to file-manager and verifies their checksums.
+// Files whose objects are missing or corrupted are marked as error. Checksums are recorded for
+// files that do not have them yet.
+//
+// Objects registered with CreateFileFromObjectPath are not read as they are owned by users.
+type Scrubber struct {
+	store       *store.S
+	objectStore ObjectStore
+	dryRun      bool
+	log         logr.Logger
+}
+
+// ScrubMismatch is a file whose object does not match the file.
+type ScrubMismatch struct {
+	FileID          string
+	ObjectStorePath string
+	Reason          string
+}
+
+// Scrub verifies all files and returns the files that do not match their objects. Files are
+// not updated in the dry-run mode.
+func (s *Scrubber) Scrub(ctx context.Context) ([]*ScrubMismatch, error) {
+	var (
+		mismatches []*ScrubMismatch
+		afterID    uint
+	)
+	for {
+		fs, err := s.store.ListFilesAfterID(afterID, scrubBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fs {
+			m, err := s.scrubFile(ctx, f)
+			if err != nil {
+				return nil, err
+			}
+			if m != nil {
+				mismatches = append(mismatches, m)
+			}
+		}
+		if len(fs) < scrubBatchSize {
+			return mismatches, nil
+		}
+		afterID = fs[len(fs)-1].ID
+	}
+}
+
+func (s *Scrubber) scrubFile(ctx context.Context, f *store.File) (*ScrubMismatch, error) {
+	if isExternalObjectPath(f.ObjectStorePath) {
+		return nil, nil
+	}
+
+	reason, sums, err := s.verifyObject(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		s.log.Info("Found a mismatch", "fileID", f.FileID, "reason", reason)
+		if !s.dryRun {
+			if err := s.store.UpdateFileStatus(f.FileID, store.FileStatusError, reason); err != nil {
+				return nil, fmt.Errorf("update file status: %s", err)
+			}
+		}
+		return &ScrubMismatch{
+			FileID:          f.FileID,
+			ObjectStorePath: f.ObjectStorePath,
+			Reason:          reason,
+		}, nil
+	}
+
+	if f.SHA256 == "" && !s.dryRun {
+		if err := s.store.UpdateFileChecksums(f.FileID, sums.sha256Hex(), sums.md5Hex()); err != nil {
+			return nil, fmt.Errorf("update file checksums: %s", err)
+		}
+		s.log.Info("Recorded the checksums", "fileID", f.FileID)
+	}
+	return nil, nil
+}
+
+// verifyObject reads the object of the file and returns the reason of a mismatch. The reason is empty
+// if the object matches the file.
+func (s *Scrubber) verifyObject(ctx context.Context, f *store.File) (string, *checksums, error) {
+	enc := fileEncryption(f)
+	size, _, _, err := s.objectStore.Stat(ctx, f.ObjectStorePath, enc)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "object not found", nil, nil
+		}
+		return "", nil, fmt.Errorf("stat object %q: %s", f.ObjectStorePath, err)
+	}
+	if size != f.Bytes {
+		return fmt.Sprintf("size mismatch: expected %d bytes, got %d bytes", f.Bytes, size), nil, nil
+	}
+
+	sums := newChecksums()
+	if _, err := io.Copy(sums, newObjectReader(ctx, s.objectStore, f.ObjectStorePath, size, enc)); err != nil {
+		return "", nil, fmt.Errorf("read object %q: %s", f.ObjectStorePath, err)
+	}
+	if f.SHA256 != "" && f.SHA256 != sums.sha256Hex() {
+		return fmt.Sprintf("sha256 mismatch: expected %s, got %s", f.SHA256, sums.sha256Hex()), nil, nil
+	}
+	if f.MD5 != "" && f.MD5 != sums.md5Hex() {
+		return fmt.Sprintf("md5 mismatch: expected %s, got %s", f.MD5, sums.md5Hex()), nil, nil
+	}
+	return "", sums, nil
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/llmariner/api-usage/pkg/sender"
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	helloMD5    = "5d41402abc4b2a76b9719d911017c592"
+)
+
+func TestScrub(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	// The checksums are computed while the file is uploaded.
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	err := w.WriteField("purpose", purposeAssistants)
+	assert.NoError(t, err)
+	fw, err := w.CreateFormFile("file", "hello.txt")
+	assert.NoError(t, err)
+	_, err = fw.Write([]byte("hello"))
+	assert.NoError(t, err)
+	err = w.Close()
+	assert.NoError(t, err)
+	req, err := http.NewRequest("POST", "v1/files", &b)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	rr := httptest.NewRecorder()
+	srv.CreateFile(rr, req, nil)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var fj fileJSON
+	err = json.Unmarshal(rr.Body.Bytes(), &fj)
+	assert.NoError(t, err)
+
+	f0, err := srv.GetFile(ctx, &v1.GetFileRequest{Id: fj.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, helloSHA256, f0.Sha256)
+	assert.Equal(t, helloMD5, f0.Md5)
+
+	isrv := NewInternal(st, testr.New(t))
+	path, err := isrv.GetFilePath(context.Background(), &v1.GetFilePathRequest{Id: fj.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, helloSHA256, path.Sha256)
+
+	// Files without checksums, a missing object, and an external object.
+	objectStore.objs["pathPrefix/f1"] = []byte("hello")
+	for _, spec := range []store.FileSpec{
+		{FileID: "f1", ObjectStorePath: "pathPrefix/f1", Bytes: 5},
+		{FileID: "f2", ObjectStorePath: "pathPrefix/f2", Bytes: 5},
+		{FileID: "f3", ObjectStorePath: "s3://bucket/f3", Bytes: 5},
+	} {
+		spec.TenantID = defaultTenantID
+		spec.ProjectID = defaultProjectID
+		_, err := st.CreateFile(spec)
+		assert.NoError(t, err)
+	}
+
+	// Corrupt the uploaded object.
+	objectStore.objs[f0.ObjectStorePath] = []byte("HELLO")
+
+	// Files are not updated in the dry-run mode.
+	ms, err := NewScrubber(st, objectStore, true, testr.New(t)).Scrub(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, ms, 2)
+	f1, err := st.GetFileByFileID("f1")
+	assert.NoError(t, err)
+	assert.Empty(t, f1.SHA256)
+
+	ms, err = NewScrubber(st, objectStore, false, testr.New(t)).Scrub(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, ms, 2)
+	assert.Equal(t, fj.ID, ms[0].FileID)
+	assert.Contains(t, ms[0].Reason, "sha256 mismatch")
+	assert.Equal(t, "f2", ms[1].FileID)
+	assert.Equal(t, "object not found", ms[1].Reason)
+
+	got, err := st.GetFileByFileID(fj.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, store.FileStatusError, got.Status)
+	assert.Contains(t, got.StatusDetails, "sha256 mismatch")
+
+	f1, err = st.GetFileByFileID("f1")
+	assert.NoError(t, err)
+	assert.Equal(t, store.FileStatusProcessed, f1.Status)
+	assert.Equal(t, helloSHA256, f1.SHA256)
+	assert.Equal(t, helloMD5, f1.MD5)
+
+	f3, err := st.GetFileByFileID("f3")
+	assert.NoError(t, err)
+	assert.Equal(t, store.FileStatusProcessed, f3.Status)
+}
@@ -2,15 +2,20 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/llmariner/api-usage/pkg/sender"
 	v1 "github.com/llmariner/file-manager/api/v1"
 	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/objectstore"
 	"github.com/llmariner/file-manager/server/internal/store"
 	"github.com/llmariner/rbac-manager/pkg/auth"
 	"google.golang.org/grpc"
@@ -24,16 +29,135 @@ const (
 	defaultTenantID  = "default-tenant-id"
 )
 
-// S3Client is an interface for an S3 client.
-type S3Client interface {
-	Upload(ctx context.Context, r io.Reader, key string) error
+// ObjectStore is an interface for an object store backend. Keys are relative to the bucket or the root
+// directory of the backend. Implementations are internal/s3.Client and internal/filesystem.Client.
+//
+// Objects in the bucket are encrypted with the given server-side encryption. The same encryption must be
+// given to read them. Objects in other buckets are not encrypted by file-manager.
+type ObjectStore interface {
+	// EncryptionMode returns the server-side encryption mode applied to new objects of the tenant.
+	EncryptionMode(tenantID string) string
+
+	// Upload uploads the content to the object and returns its ETag.
+	Upload(ctx context.Context, r io.Reader, key string, enc objectstore.Encryption) (string, error)
+	// Download returns the content of the object in the given byte range.
+	Download(ctx context.Context, key string, offset, length int64, enc objectstore.Encryption) (io.ReadCloser, error)
+	// DownloadBucketObject is the same as Download, but for an object in the given bucket.
+	DownloadBucketObject(ctx context.Context, bucket, key string, offset, length int64) (io.ReadCloser, error)
+	// Copy copies the object to another key. The copy is encrypted in the same way as the source.
+	// It returns an error wrapping errors.ErrUnsupported if copying objects is not supported.
+	Copy(ctx context.Context, srcKey, dstKey string, enc objectstore.Encryption) error
+	// Delete deletes the object. It does not return an error if the object does not exist.
+	Delete(ctx context.Context, key string) error
+	// List calls the function for each object whose key has the given prefix.
+	List(ctx context.Context, prefix string, f func(key string, lastModified time.Time) error) error
+	// Stat returns the size, the ETag, and the last modified time of the object. The ETag is empty if the
+	// object store does not record it. It returns an error wrapping fs.ErrNotExist if the object does not exist.
+	Stat(ctx context.Context, key string, enc objectstore.Encryption) (int64, string, time.Time, error)
+	// StatBucketObject is the same as Stat, but for an object in the given bucket. This is used for
+	// objects registered with CreateFileFromObjectPath.
+	StatBucketObject(ctx context.Context, bucket, key string) (int64, string, time.Time, error)
+
+	// PresignUpload returns a presigned URL to upload the object and the expiration time of the URL.
+	// It returns an error wrapping errors.ErrUnsupported if presigned URLs are not supported.
+	PresignUpload(ctx context.Context, key string, enc objectstore.Encryption) (string, time.Time, error)
+	// PresignDownload returns a presigned URL to download the object as an attachment with the given filename
+	// and the expiration time of the URL. It returns an error wrapping errors.ErrUnsupported if presigned URLs
+	// are not supported.
+	PresignDownload(ctx context.Context, key, filename string, enc objectstore.Encryption) (string, time.Time, error)
+
+	// CreateMultipartUpload starts a multipart upload to the object and returns its upload ID.
+	CreateMultipartUpload(ctx context.Context, key string, enc objectstore.Encryption) (string, error)
+	// UploadPartCopy copies the object of srcKey to a part of a multipart upload and returns the ETag of the part.
+	// The source object must be encrypted in the same way as the multipart upload.
+	UploadPartCopy(ctx context.Context, key, uploadID string, partNumber int32, srcKey string, enc objectstore.Encryption) (string, error)
+	// CompleteMultipartUpload completes a multipart upload with the parts keyed by their part numbers
+	// and returns the ETag of the object.
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, partETags map[int32]string, enc objectstore.Encryption) (string, error)
+	// AbortMultipartUpload aborts a multipart upload.
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+}
+
+// NoopObjectStore is a no-op object store. It is used when no object store is configured.
+type NoopObjectStore struct{}
+
+// EncryptionMode is a no-op implementation of EncryptionMode. Objects are not encrypted.
+func (n *NoopObjectStore) EncryptionMode(tenantID string) string {
+	return ""
+}
+
+// Upload is a no-op implementation of Upload. It discards the content.
+func (n *NoopObjectStore) Upload(ctx context.Context, r io.Reader, key string, enc objectstore.Encryption) (string, error) {
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// Download is a no-op implementation of Download. It returns an empty content.
+func (n *NoopObjectStore) Download(ctx context.Context, key string, offset, length int64, enc objectstore.Encryption) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+// DownloadBucketObject is a no-op implementation of DownloadBucketObject. Objects in other buckets
+// are not accessible.
+func (n *NoopObjectStore) DownloadBucketObject(ctx context.Context, bucket, key string, offset, length int64) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("download bucket object: %w", errors.ErrUnsupported)
+}
+
+// Copy is a no-op implementation of Copy. Copying objects is not supported.
+func (n *NoopObjectStore) Copy(ctx context.Context, srcKey, dstKey string, enc objectstore.Encryption) error {
+	return fmt.Errorf("copy object: %w", errors.ErrUnsupported)
+}
+
+// Delete is a no-op implementation of Delete.
+func (n *NoopObjectStore) Delete(ctx context.Context, key string) error {
+	return nil
 }
 
-// NoopS3Client is a no-op S3 client.
-type NoopS3Client struct{}
+// List is a no-op implementation of List. It does not list any object.
+func (n *NoopObjectStore) List(ctx context.Context, prefix string, f func(key string, lastModified time.Time) error) error {
+	return nil
+}
+
+// Stat is a no-op implementation of Stat. It reports that the object does not exist.
+func (n *NoopObjectStore) Stat(ctx context.Context, key string, enc objectstore.Encryption) (int64, string, time.Time, error) {
+	return 0, "", time.Time{}, fmt.Errorf("object %q: %w", key, fs.ErrNotExist)
+}
+
+// StatBucketObject is a no-op implementation of StatBucketObject. Objects in other buckets
+// are not accessible.
+func (n *NoopObjectStore) StatBucketObject(ctx context.Context, bucket, key string) (int64, string, time.Time, error) {
+	return 0, "", time.Time{}, fmt.Errorf("stat bucket object: %w", errors.ErrUnsupported)
+}
+
+// PresignUpload is a no-op implementation of PresignUpload. Presigned URLs are not supported.
+func (n *NoopObjectStore) PresignUpload(ctx context.Context, key string, enc objectstore.Encryption) (string, time.Time, error) {
+	return "", time.Time{}, fmt.Errorf("presigned URL: %w", errors.ErrUnsupported)
+}
+
+// PresignDownload is a no-op implementation of PresignDownload. Presigned URLs are not supported.
+func (n *NoopObjectStore) PresignDownload(ctx context.Context, key, filename string, enc objectstore.Encryption) (string, time.Time, error) {
+	return "", time.Time{}, fmt.Errorf("presigned URL: %w", errors.ErrUnsupported)
+}
+
+// CreateMultipartUpload is a no-op implementation of CreateMultipartUpload.
+func (n *NoopObjectStore) CreateMultipartUpload(ctx context.Context, key string, enc objectstore.Encryption) (string, error) {
+	return "noop", nil
+}
+
+// UploadPartCopy is a no-op implementation of UploadPartCopy.
+func (n *NoopObjectStore) UploadPartCopy(ctx context.Context, key, uploadID string, partNumber int32, srcKey string, enc objectstore.Encryption) (string, error) {
+	return "", nil
+}
+
+// CompleteMultipartUpload is a no-op implementation of CompleteMultipartUpload.
+func (n *NoopObjectStore) CompleteMultipartUpload(ctx context.Context, key, uploadID string, partETags map[int32]string, enc objectstore.Encryption) (string, error) {
+	return "", nil
+}
 
-// Upload is a no-op implementation of Upload.
-func (n *NoopS3Client) Upload(ctx context.Context, r io.Reader, key string) error {
+// AbortMultipartUpload is a no-op implementation of AbortMultipartUpload.
+func (n *NoopObjectStore) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
 	return nil
 }
 
@@ -60,14 +184,25 @@ func (n noopReqIntercepter) InterceptHTTPRequest(req *http.Request) (int, auth.U
 }
 
 // New creates a server.
-func New(store *store.S, s3Client S3Client, sender sender.UsageSetter, pathPrefix string, enableFileUpload bool, log logr.Logger) *S {
+func New(
+	store *store.S,
+	objectStore ObjectStore,
+	sender sender.UsageSetter,
+	pathPrefix string,
+	enableFileUpload bool,
+	quota config.QuotaConfig,
+	enableDeduplication bool,
+	log logr.Logger,
+) *S {
 	return &S{
 		store:            store,
-		s3Client:         s3Client,
+		objectStore:      objectStore,
 		usage:            sender,
 		log:              log.WithName("grpc"),
 		pathPrefix:       pathPrefix,
 		enableFileUpload: enableFileUpload,
+		quota:            quota,
+		enableDedup:      enableDeduplication,
 		reqIntercepter:   noopReqIntercepter{},
 	}
 }
@@ -79,9 +214,11 @@ type S struct {
 	srv *grpc.Server
 
 	store            *store.S
-	s3Client         S3Client
+	objectStore      ObjectStore
 	usage            sender.UsageSetter
 	enableFileUpload bool
+	quota            config.QuotaConfig
+	enableDedup      bool
 	log              logr.Logger
 
 	pathPrefix string
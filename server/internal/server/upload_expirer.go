@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"gorm.io/gorm"
+)
+
+// NewUploadExpirer creates a new upload expirer.
+func NewUploadExpirer(st *store.S, objectStore ObjectStore, log logr.Logger) *UploadExpirer {
+	return &UploadExpirer{
+		store:       st,
+		objectStore: objectStore,
+		log:         log.WithName("upload-expirer"),
+	}
+}
+
+// UploadExpirer marks pending uploads past their expiration as expired and aborts their
+// multipart uploads. It also deletes expired presigned uploads. Their objects are deleted by
+// the garbage collector.
+type UploadExpirer struct {
+	store       *store.S
+	objectStore ObjectStore
+	log         logr.Logger
+}
+
+// Run periodically expires uploads.
+func (e *UploadExpirer) Run(ctx context.Context, interval time.Duration) error {
+	e.log.Info("Starting upload expirer...", "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := e.expireUploads(ctx, time.Now()); err != nil {
+			e.log.Error(err, "Failed to expire uploads")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *UploadExpirer) expireUploads(ctx context.Context, now time.Time) error {
+	us, err := e.store.ListExpiredPendingUploads(now)
+	if err != nil {
+		return err
+	}
+	for _, u := range us {
+		if err := e.store.UpdateUploadStatus(u.UploadID, store.UploadStatusPending, store.UploadStatusExpired); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// The upload has been completed or cancelled concurrently.
+				continue
+			}
+			return err
+		}
+		deleteUploadParts(e.store, u, e.log)
+		if err := e.objectStore.AbortMultipartUpload(ctx, u.ObjectStorePath, u.MultipartUploadID); err != nil {
+			e.log.Error(err, "Failed to abort the multipart upload", "uploadID", u.UploadID)
+			continue
+		}
+		e.log.Info("Expired the upload", "uploadID", u.UploadID)
+	}
+
+	n, err := e.store.DeleteExpiredPresignedUploads(now)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		e.log.Info("Deleted expired presigned uploads", "count", n)
+	}
+	return nil
+}
@@ -0,0 +1,538 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	auv1 "github.com/llmariner/api-usage/api/v1"
+	"github.com/llmariner/common/pkg/id"
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/objectstore"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/llmariner/rbac-manager/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	// uploadExpiration is the duration after which a pending upload expires.
+	uploadExpiration = time.Hour
+	// maxUploadBytes is the maximum size of a file uploaded with an upload.
+	maxUploadBytes = 8 << 30
+	// maxUploadPartBytes is the maximum size of a part.
+	maxUploadPartBytes = 64 << 20
+	// minUploadPartBytes is the minimum size of a part other than the last one. This is the
+	// minimum size of a part of a S3 multipart upload.
+	minUploadPartBytes = 5 << 20
+)
+
+// CreateUpload creates an upload.
+func (s *S) CreateUpload(
+	ctx context.Context,
+	req *v1.CreateUploadRequest,
+) (*v1.Upload, error) {
+	userInfo, ok := auth.ExtractUserInfoFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to extract user info from context")
+	}
+
+	if !s.enableFileUpload {
+		return nil, status.Error(codes.PermissionDenied, fileUploadDisabledMsg)
+	}
+
+	if req.Filename == "" {
+		return nil, status.Error(codes.InvalidArgument, "filename is required")
+	}
+	rule, err := validateUploadPurpose(req.Purpose)
+	if err != nil {
+		return nil, err
+	}
+	if err := rule.validateFilename(req.Filename); err != nil {
+		return nil, err
+	}
+	if req.Bytes <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "bytes must be greater than 0")
+	}
+	if req.Bytes > maxUploadBytes {
+		return nil, status.Errorf(codes.InvalidArgument, "bytes must not exceed %d", maxUploadBytes)
+	}
+	if err := rule.validateSize(req.Bytes); err != nil {
+		return nil, err
+	}
+	if err := s.checkQuota(userInfo.TenantID, userInfo.ProjectID, req.Bytes); err != nil {
+		return nil, err
+	}
+
+	uploadID, err := id.GenerateID("upload_", 24)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate upload id: %s", err)
+	}
+	// Generate the file ID here so that the object key follows the same convention as
+	// the files uploaded with CreateFile.
+	fileID, err := id.GenerateID("file-", 24)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate file id: %s", err)
+	}
+	path := s.filePath(fileID)
+
+	enc := s.newObjectEncryption(userInfo.TenantID)
+	mpuID, err := s.objectStore.CreateMultipartUpload(ctx, path, enc)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create multipart upload: %s", err)
+	}
+
+	u := &store.Upload{
+		UploadID:       uploadID,
+		TenantID:       userInfo.TenantID,
+		OrganizationID: userInfo.OrganizationID,
+		ProjectID:      userInfo.ProjectID,
+
+		Filename: req.Filename,
+		Purpose:  req.Purpose,
+		MimeType: req.MimeType,
+		Bytes:    req.Bytes,
+
+		Status:    store.UploadStatusPending,
+		ExpiresAt: time.Now().Add(uploadExpiration),
+
+		FileID:            fileID,
+		ObjectStorePath:   path,
+		MultipartUploadID: mpuID,
+		EncryptionMode:    enc.Mode,
+	}
+	if err := s.store.CreateUpload(u); err != nil {
+		if err := s.objectStore.AbortMultipartUpload(ctx, path, mpuID); err != nil {
+			s.log.Error(err, "Failed to abort the multipart upload", "path", path)
+		}
+		return nil, status.Errorf(codes.Internal, "create upload: %s", err)
+	}
+	return toUploadProto(u, nil), nil
+}
+
+// AddUploadPart adds a part to an upload.
+func (s *S) AddUploadPart(
+	w http.ResponseWriter,
+	req *http.Request,
+	pathParams map[string]string,
+) {
+	start := time.Now()
+	status, userInfo, err := s.reqIntercepter.InterceptHTTPRequest(req)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	usage := auv1.UsageRecord{
+		UserId:       userInfo.InternalUserID,
+		Tenant:       userInfo.TenantID,
+		Organization: userInfo.OrganizationID,
+		Project:      userInfo.ProjectID,
+		ApiMethod:    "/llmariner.files.server.v1.FileService/AddUploadPart",
+		StatusCode:   http.StatusOK,
+		Timestamp:    start.UnixNano(),
+	}
+	defer func() {
+		usage.LatencyMs = int32(time.Since(start).Milliseconds())
+		s.usage.AddUsage(&usage)
+	}()
+
+	if !s.enableFileUpload {
+		httpError(w, fileUploadDisabledMsg, http.StatusForbidden, &usage)
+		return
+	}
+
+	uploadID := pathParams["id"]
+	if uploadID == "" {
+		httpError(w, "id is required", http.StatusBadRequest, &usage)
+		return
+	}
+
+	u, err := s.store.GetUploadByUploadIDAndProjectID(uploadID, userInfo.ProjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			httpError(w, fmt.Sprintf("upload %q not found", uploadID), http.StatusNotFound, &usage)
+			return
+		}
+		httpError(w, fmt.Sprintf("get upload: %s", err), http.StatusInternalServerError, &usage)
+		return
+	}
+	if err := validatePendingUpload(u, time.Now()); err != nil {
+		httpError(w, err.Error(), http.StatusBadRequest, &usage)
+		return
+	}
+
+	mr, err := req.MultipartReader()
+	if err != nil {
+		httpError(w, err.Error(), http.StatusBadRequest, &usage)
+		return
+	}
+	partID, err := id.GenerateID("part_", 24)
+	if err != nil {
+		httpError(w, fmt.Sprintf("generate part id: %s", err), http.StatusInternalServerError, &usage)
+		return
+	}
+	// Store the part in a separate object so that the parts can be assembled in the order
+	// given when the upload is completed.
+	path := uploadPartPath(u, partID)
+	var (
+		etag  string
+		bytes int64
+		found bool
+	)
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			httpError(w, err.Error(), http.StatusBadRequest, &usage)
+			return
+		}
+		if part.FormName() != "data" {
+			continue
+		}
+		r := &countingReader{r: part, limit: maxUploadPartBytes}
+		etag, err = s.objectStore.Upload(req.Context(), r, path, uploadEncryption(u))
+		if err != nil {
+			s.enqueueObjectDeletion(path)
+			if r.exceeded() {
+				httpError(w, fmt.Sprintf("part must not exceed %d bytes", maxUploadPartBytes), http.StatusBadRequest, &usage)
+				return
+			}
+			httpError(w, fmt.Sprintf("upload part: %s", err), http.StatusInternalServerError, &usage)
+			return
+		}
+		bytes = r.n
+		found = true
+		break
+	}
+	if !found {
+		httpError(w, "data is required", http.StatusBadRequest, &usage)
+		return
+	}
+
+	partNumber, err := s.store.AllocateUploadPartNumber(u.UploadID)
+	if err != nil {
+		s.enqueueObjectDeletion(path)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			httpError(w, fmt.Sprintf("upload %q is not pending", uploadID), http.StatusBadRequest, &usage)
+			return
+		}
+		httpError(w, fmt.Sprintf("allocate part number: %s", err), http.StatusInternalServerError, &usage)
+		return
+	}
+
+	p := &store.UploadPart{
+		PartID:     partID,
+		UploadID:   u.UploadID,
+		PartNumber: partNumber,
+		ETag:       etag,
+		Bytes:      bytes,
+	}
+	if err := s.store.CreateUploadPart(p); err != nil {
+		s.enqueueObjectDeletion(path)
+		httpError(w, fmt.Sprintf("create upload part: %s", err), http.StatusInternalServerError, &usage)
+		return
+	}
+
+	b, err := json.Marshal(toUploadPartJSON(p))
+	if err != nil {
+		httpError(w, err.Error(), http.StatusInternalServerError, &usage)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(b); err != nil {
+		httpError(w, err.Error(), http.StatusInternalServerError, &usage)
+		return
+	}
+}
+
+// CompleteUpload completes an upload and creates a file.
+func (s *S) CompleteUpload(
+	ctx context.Context,
+	req *v1.CompleteUploadRequest,
+) (*v1.Upload, error) {
+	userInfo, ok := auth.ExtractUserInfoFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to extract user info from context")
+	}
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if len(req.PartIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "part_ids is required")
+	}
+
+	u, err := s.store.GetUploadByUploadIDAndProjectID(req.Id, userInfo.ProjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "upload %q not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "get upload: %s", err)
+	}
+	if err := validatePendingUpload(u, time.Now()); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	ps, err := s.store.ListUploadPartsByUploadID(u.UploadID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list upload parts: %s", err)
+	}
+	partsByID := map[string]*store.UploadPart{}
+	for _, p := range ps {
+		partsByID[p.PartID] = p
+	}
+
+	var total int64
+	listed := map[string]bool{}
+	for i, pid := range req.PartIds {
+		p, ok := partsByID[pid]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "part %q not found", pid)
+		}
+		if listed[pid] {
+			return nil, status.Errorf(codes.InvalidArgument, "part %q is listed more than once", pid)
+		}
+		listed[pid] = true
+		if i < len(req.PartIds)-1 && p.Bytes < minUploadPartBytes {
+			return nil, status.Errorf(codes.InvalidArgument, "part %q is %d bytes, but parts other than the last one must be at least %d bytes", pid, p.Bytes, minUploadPartBytes)
+		}
+		total += p.Bytes
+	}
+	if total != u.Bytes {
+		return nil, status.Errorf(codes.InvalidArgument, "total size of the parts (%d) does not match the upload size (%d)", total, u.Bytes)
+	}
+
+	// Check the quotas before completing the multipart upload so that the upload can be completed
+	// after files are deleted.
+	if err := s.checkQuota(u.TenantID, u.ProjectID, total); err != nil {
+		return nil, err
+	}
+
+	rule, err := lookupPurposeRule(u.Purpose)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "lookup purpose: %s", err)
+	}
+
+	// Assemble the parts in the order of part_ids.
+	partETags := map[int32]string{}
+	for i, pid := range req.PartIds {
+		n := int32(i + 1)
+		etag, err := s.objectStore.UploadPartCopy(ctx, u.ObjectStorePath, u.MultipartUploadID, n, uploadPartPath(u, pid), uploadEncryption(u))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "copy part %q: %s", pid, err)
+		}
+		partETags[n] = etag
+	}
+	etag, err := s.objectStore.CompleteMultipartUpload(ctx, u.ObjectStorePath, u.MultipartUploadID, partETags, uploadEncryption(u))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "complete multipart upload: %s", err)
+	}
+
+	// Validate the content and verify the checksum in the same way as CreateFile as the content is not
+	// streamed through the server. The upload is cancelled if it is rejected as the multipart upload
+	// cannot be completed again.
+	cv, sums, err := s.scanUploadedObject(ctx, rule, u.ObjectStorePath, total, uploadEncryption(u))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "read uploaded object: %s", err)
+	}
+	var (
+		validationStatus store.ValidationStatus
+		lines            int64
+	)
+	if cv != nil {
+		if !cv.Valid() {
+			s.rejectCompletedUpload(u)
+			return nil, contentValidationError(u.Purpose, cv)
+		}
+		validationStatus = store.ValidationStatusValid
+		lines = int64(cv.Lines())
+	}
+	if req.Md5 != "" && !strings.EqualFold(req.Md5, sums.md5Hex()) {
+		s.rejectCompletedUpload(u)
+		return nil, status.Errorf(codes.InvalidArgument, "md5 mismatch: expected %s, got %s", req.Md5, sums.md5Hex())
+	}
+
+	var f *store.File
+	if err := s.store.Transaction(func(tx *gorm.DB) error {
+		if err := store.UpdateUploadStatusInTransaction(tx, u.UploadID, store.UploadStatusPending, store.UploadStatusCompleted); err != nil {
+			return err
+		}
+		if err := s.checkQuotaInTransaction(tx, u.TenantID, u.ProjectID, total); err != nil {
+			return err
+		}
+		var err error
+		f, err = store.CreateFileInTransaction(tx, store.FileSpec{
+			FileID:         u.FileID,
+			TenantID:       u.TenantID,
+			OrganizationID: u.OrganizationID,
+			ProjectID:      u.ProjectID,
+
+			Purpose:  u.Purpose,
+			Filename: u.Filename,
+			Bytes:    total,
+
+			ObjectStorePath: u.ObjectStorePath,
+			ETag:            etag,
+
+			ValidationStatus: validationStatus,
+			Lines:            lines,
+
+			SHA256: sums.sha256Hex(),
+			MD5:    sums.md5Hex(),
+
+			EncryptionMode: u.EncryptionMode,
+		})
+		return err
+	}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The upload has been completed or cancelled concurrently.
+			cur, gerr := s.store.GetUploadByUploadIDAndProjectID(u.UploadID, u.ProjectID)
+			if gerr != nil || cur.Status != store.UploadStatusCompleted {
+				s.enqueueObjectDeletion(u.ObjectStorePath)
+			}
+			return nil, status.Errorf(codes.FailedPrecondition, "upload %q is not pending", u.UploadID)
+		}
+		s.enqueueObjectDeletion(u.ObjectStorePath)
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "complete upload: %s", err)
+	}
+	deleteUploadParts(s.store, u, s.log)
+	u.Status = store.UploadStatusCompleted
+	return toUploadProto(u, f), nil
+}
+
+// CancelUpload cancels an upload.
+func (s *S) CancelUpload(
+	ctx context.Context,
+	req *v1.CancelUploadRequest,
+) (*v1.Upload, error) {
+	userInfo, ok := auth.ExtractUserInfoFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to extract user info from context")
+	}
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	u, err := s.store.GetUploadByUploadIDAndProjectID(req.Id, userInfo.ProjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "upload %q not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "get upload: %s", err)
+	}
+
+	if err := s.store.UpdateUploadStatus(u.UploadID, store.UploadStatusPending, store.UploadStatusCancelled); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "upload %q is not pending", u.UploadID)
+		}
+		return nil, status.Errorf(codes.Internal, "update upload: %s", err)
+	}
+	if err := s.objectStore.AbortMultipartUpload(ctx, u.ObjectStorePath, u.MultipartUploadID); err != nil {
+		// Do not fail as the upload has been cancelled. The object store is expected to
+		// clean up incomplete multipart uploads.
+		s.log.Error(err, "Failed to abort the multipart upload", "uploadID", u.UploadID)
+	}
+	deleteUploadParts(s.store, u, s.log)
+	u.Status = store.UploadStatusCancelled
+	return toUploadProto(u, nil), nil
+}
+
+// rejectCompletedUpload cancels an upload whose multipart upload has been completed but whose object
+// has been rejected, and deletes the object.
+func (s *S) rejectCompletedUpload(u *store.Upload) {
+	if err := s.store.UpdateUploadStatus(u.UploadID, store.UploadStatusPending, store.UploadStatusCancelled); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The upload has been completed or cancelled concurrently.
+			return
+		}
+		s.log.Error(err, "Failed to cancel the upload", "uploadID", u.UploadID)
+	}
+	s.enqueueObjectDeletion(u.ObjectStorePath)
+	deleteUploadParts(s.store, u, s.log)
+}
+
+// deleteUploadParts enqueues the deletion of the objects of the parts of an upload that is no longer pending.
+func deleteUploadParts(st *store.S, u *store.Upload, log logr.Logger) {
+	ps, err := st.ListUploadPartsByUploadID(u.UploadID)
+	if err != nil {
+		log.Error(err, "Failed to list the upload parts", "uploadID", u.UploadID)
+		return
+	}
+	for _, p := range ps {
+		path := uploadPartPath(u, p.PartID)
+		if _, err := st.CreateObjectDeletion(path, time.Now()); err != nil {
+			log.Error(err, "Failed to enqueue the object deletion", "path", path)
+		}
+	}
+}
+
+// uploadPartPath returns the object store path of the part of the upload.
+func uploadPartPath(u *store.Upload, partID string) string {
+	return u.ObjectStorePath + ".parts/" + partID
+}
+
+// validatePendingUpload returns an error if the upload does not accept any more changes.
+func validatePendingUpload(u *store.Upload, now time.Time) error {
+	if u.Status != store.UploadStatusPending {
+		return fmt.Errorf("upload %q is %s", u.UploadID, u.Status)
+	}
+	if !now.Before(u.ExpiresAt) {
+		return fmt.Errorf("upload %q has expired", u.UploadID)
+	}
+	return nil
+}
+
+func toUploadProto(u *store.Upload, f *store.File) *v1.Upload {
+	up := &v1.Upload{
+		Id:        u.UploadID,
+		Object:    "upload",
+		Bytes:     u.Bytes,
+		CreatedAt: u.CreatedAt.UTC().Unix(),
+		Filename:  u.Filename,
+		Purpose:   u.Purpose,
+		Status:    string(u.Status),
+		ExpiresAt: u.ExpiresAt.UTC().Unix(),
+	}
+	if f != nil {
+		up.File = toFileProto(f)
+	}
+	return up
+}
+
+type uploadPartJSON struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	CreatedAt int64  `json:"created_at"`
+	UploadID  string `json:"upload_id"`
+}
+
+func toUploadPartJSON(p *store.UploadPart) *uploadPartJSON {
+	return &uploadPartJSON{
+		ID:        p.PartID,
+		Object:    "upload.part",
+		CreatedAt: p.CreatedAt.UTC().Unix(),
+		UploadID:  p.UploadID,
+	}
+}
+
+// uploadEncryption returns the server-side encryption of the object of the upload.
+func uploadEncryption(u *store.Upload) objectstore.Encryption {
+	return objectstore.Encryption{
+		TenantID: u.TenantID,
+		Mode:     u.EncryptionMode,
+	}
+}
@@ -0,0 +1,341 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/llmariner/api-usage/pkg/sender"
+	v1 "github.com/llmariner/file-manager/api/v1"
+	"github.com/llmariner/file-manager/server/internal/config"
+	"github.com/llmariner/file-manager/server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUploads(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	// The content has three parts, and the first two parts have the minimum size.
+	lines := 2*minUploadPartBytes/(len(fineTuneLine)+1) + 10
+	content := strings.Repeat(fineTuneLine+"\n", lines)
+	chunks := []string{
+		content[:minUploadPartBytes],
+		content[minUploadPartBytes : 2*minUploadPartBytes],
+		content[2*minUploadPartBytes:],
+	}
+
+	u, err := srv.CreateUpload(ctx, &v1.CreateUploadRequest{
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+		Bytes:    int64(len(content)),
+		MimeType: "text/jsonl",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "upload", u.Object)
+	assert.Equal(t, "pending", u.Status)
+	assert.Nil(t, u.File)
+
+	// Parts can be added in any order.
+	partIDs := make([]string, len(chunks))
+	for _, i := range []int{2, 0, 1} {
+		code, p := addUploadPart(t, srv, u.Id, chunks[i])
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "upload.part", p.Object)
+		assert.Equal(t, u.Id, p.UploadID)
+		partIDs[i] = p.ID
+	}
+
+	// Parts other than the last one must not be smaller than the minimum size.
+	_, err = srv.CompleteUpload(ctx, &v1.CompleteUploadRequest{
+		Id:      u.Id,
+		PartIds: []string{partIDs[0], partIDs[2], partIDs[1]},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), fmt.Sprintf("must be at least %d bytes", minUploadPartBytes))
+
+	// Parts must not be listed more than once.
+	_, err = srv.CompleteUpload(ctx, &v1.CompleteUploadRequest{
+		Id:      u.Id,
+		PartIds: []string{partIDs[0], partIDs[0], partIDs[2]},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The total size must match.
+	_, err = srv.CompleteUpload(ctx, &v1.CompleteUploadRequest{
+		Id:      u.Id,
+		PartIds: partIDs[:2],
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.CompleteUpload(ctx, &v1.CompleteUploadRequest{
+		Id:      u.Id,
+		PartIds: []string{"unknown"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	got, err := srv.CompleteUpload(ctx, &v1.CompleteUploadRequest{
+		Id:      u.Id,
+		PartIds: partIDs,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "completed", got.Status)
+	assert.NotNil(t, got.File)
+	assert.Equal(t, int64(len(content)), got.File.Bytes)
+	assert.Equal(t, purposeFineTune, got.File.Purpose)
+	assert.Equal(t, "test.jsonl", got.File.Filename)
+
+	f, err := srv.GetFile(ctx, &v1.GetFileRequest{Id: got.File.Id})
+	assert.NoError(t, err)
+	// The parts are assembled in the order of the part IDs.
+	assert.Equal(t, content, string(objectStore.objs[f.ObjectStorePath]))
+	sf, err := st.GetFileByFileID(f.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, store.ValidationStatusValid, sf.ValidationStatus)
+	assert.Equal(t, int64(lines), sf.Lines)
+	assert.Equal(t, fmt.Sprintf("%x", md5.Sum([]byte(content))), sf.MD5)
+
+	// The objects of the parts are deleted.
+	ds, err := st.ListDueObjectDeletions(time.Now(), 10)
+	assert.NoError(t, err)
+	var paths []string
+	for _, d := range ds {
+		paths = append(paths, d.ObjectStorePath)
+	}
+	var wantPaths []string
+	for _, pid := range partIDs {
+		wantPaths = append(wantPaths, f.ObjectStorePath+".parts/"+pid)
+	}
+	assert.ElementsMatch(t, wantPaths, paths)
+
+	// No more part can be added, and the upload cannot be completed or cancelled again.
+	code, _ := addUploadPart(t, srv, u.Id, "data")
+	assert.Equal(t, http.StatusBadRequest, code)
+	_, err = srv.CompleteUpload(ctx, &v1.CompleteUploadRequest{
+		Id:      u.Id,
+		PartIds: partIDs,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = srv.CancelUpload(ctx, &v1.CancelUploadRequest{Id: u.Id})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestCompleteUploadWithRejectedContent(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	complete := func(content, checksum string) (*v1.Upload, error) {
+		u, err := srv.CreateUpload(ctx, &v1.CreateUploadRequest{
+			Filename: "test.jsonl",
+			Purpose:  purposeFineTune,
+			Bytes:    int64(len(content)),
+		})
+		assert.NoError(t, err)
+		code, p := addUploadPart(t, srv, u.Id, content)
+		assert.Equal(t, http.StatusOK, code)
+		got, err := srv.CompleteUpload(ctx, &v1.CompleteUploadRequest{
+			Id:      u.Id,
+			PartIds: []string{p.ID},
+			Md5:     checksum,
+		})
+		if err != nil {
+			// The upload is cancelled, and its object is deleted.
+			cur, gerr := st.GetUploadByUploadIDAndProjectID(u.Id, defaultProjectID)
+			assert.NoError(t, gerr)
+			assert.Equal(t, store.UploadStatusCancelled, cur.Status)
+		}
+		return got, err
+	}
+
+	// The content is not valid for the purpose.
+	_, err := complete("hello", "")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "invalid fine-tune file: line 1: invalid JSON")
+
+	// The checksum does not match.
+	_, err = complete(fineTuneLine, fmt.Sprintf("%x", md5.Sum([]byte("hello"))))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The objects and the objects of their parts are deleted.
+	ds, err := st.ListDueObjectDeletions(time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 4)
+
+	got, err := complete(fineTuneLine, strings.ToUpper(fmt.Sprintf("%x", md5.Sum([]byte(fineTuneLine)))))
+	assert.NoError(t, err)
+	assert.Equal(t, "completed", got.Status)
+}
+
+func TestCancelUpload(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	u, err := srv.CreateUpload(ctx, &v1.CreateUploadRequest{
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+		Bytes:    5,
+	})
+	assert.NoError(t, err)
+	code, _ := addUploadPart(t, srv, u.Id, "hello")
+	assert.Equal(t, http.StatusOK, code)
+
+	got, err := srv.CancelUpload(ctx, &v1.CancelUploadRequest{Id: u.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, "cancelled", got.Status)
+	assert.Empty(t, objectStore.mpus)
+	ds, err := st.ListDueObjectDeletions(time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 1)
+
+	code, _ = addUploadPart(t, srv, u.Id, "hello")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestCreateUploadValidation(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	srv := New(st, newMemoryObjectStore(), &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	tcs := []struct {
+		name string
+		req  *v1.CreateUploadRequest
+	}{
+		{
+			name: "missing filename",
+			req:  &v1.CreateUploadRequest{Purpose: purposeFineTune, Bytes: 1},
+		},
+		{
+			name: "invalid purpose",
+			req:  &v1.CreateUploadRequest{Filename: "f", Purpose: "invalid", Bytes: 1},
+		},
+		{
+			name: "zero bytes",
+			req:  &v1.CreateUploadRequest{Filename: "f", Purpose: purposeFineTune},
+		},
+		{
+			name: "too large",
+			req:  &v1.CreateUploadRequest{Filename: "f", Purpose: purposeFineTune, Bytes: maxUploadBytes + 1},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := srv.CreateUpload(ctx, tc.req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestExpireUploads(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := newMemoryObjectStore()
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", true, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	u, err := srv.CreateUpload(ctx, &v1.CreateUploadRequest{
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+		Bytes:    5,
+	})
+	assert.NoError(t, err)
+	code, _ := addUploadPart(t, srv, u.Id, "hello")
+	assert.Equal(t, http.StatusOK, code)
+
+	e := NewUploadExpirer(st, objectStore, testr.New(t))
+	err = e.expireUploads(context.Background(), time.Now())
+	assert.NoError(t, err)
+	assert.Len(t, objectStore.mpus, 1)
+
+	err = e.expireUploads(context.Background(), time.Now().Add(uploadExpiration))
+	assert.NoError(t, err)
+	assert.Empty(t, objectStore.mpus)
+
+	got, err := st.GetUploadByUploadIDAndProjectID(u.Id, defaultProjectID)
+	assert.NoError(t, err)
+	assert.Equal(t, store.UploadStatusExpired, got.Status)
+	ds, err := st.ListDueObjectDeletions(time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 1)
+}
+
+func TestUploadsWithFileUploadDisabled(t *testing.T) {
+	st, tearDown := store.NewTest(t)
+	defer tearDown()
+
+	objectStore := &memoryObjectStore{objs: map[string][]byte{"s3://bucket/test.jsonl": []byte("hello")}}
+	srv := New(st, objectStore, &sender.NoopUsageSetter{}, "pathPrefix", false, config.QuotaConfig{}, false, testr.New(t))
+	ctx := fakeAuthInto(context.Background())
+
+	_, err := srv.CreateUpload(ctx, &v1.CreateUploadRequest{
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+		Bytes:    5,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	code, _ := addUploadPart(t, srv, "upload_id", "hello")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	_, err = srv.CreateFileUploadURL(ctx, &v1.CreateFileUploadURLRequest{
+		Filename: "test.jsonl",
+		Purpose:  purposeFineTune,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Files can still be created from object paths.
+	_, err = srv.CreateFileFromObjectPath(ctx, &v1.CreateFileFromObjectPathRequest{
+		ObjectPath: "s3://bucket/test.jsonl",
+		Purpose:    purposeFineTune,
+	})
+	assert.NoError(t, err)
+}
+
+func addUploadPart(t *testing.T, srv *S, uploadID, data string) (int, *uploadPartJSON) {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	fw, err := w.CreateFormFile("data", "blob")
+	assert.NoError(t, err)
+	_, err = fw.Write([]byte(data))
+	assert.NoError(t, err)
+	err = w.Close()
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "v1/uploads/"+uploadID+"/parts", &b)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	rr := httptest.NewRecorder()
+	srv.AddUploadPart(rr, req, map[string]string{"id": uploadID})
+	if rr.Code != http.StatusOK {
+		return rr.Code, nil
+	}
+	var p uploadPartJSON
+	err = json.Unmarshal(rr.Body.Bytes(), &p)
+	assert.NoError(t, err)
+	return rr.Code, &p
+}
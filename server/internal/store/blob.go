@@ -0,0 +1,86 @@
+package store
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Blob represents an object shared by files having the same content in a tenant.
+type Blob struct {
+	gorm.Model
+
+	TenantID string `gorm:"uniqueIndex:idx_blob_tenant_id_sha256_encryption_mode"`
+	SHA256   string `gorm:"uniqueIndex:idx_blob_tenant_id_sha256_encryption_mode"`
+	// EncryptionMode is the server-side encryption mode of the object. Objects with the same content are
+	// not shared across modes so that files are always stored with the mode configured when they are created.
+	EncryptionMode string `gorm:"uniqueIndex:idx_blob_tenant_id_sha256_encryption_mode"`
+
+	Bytes           int64
+	ObjectStorePath string `gorm:"uniqueIndex"`
+
+	// RefCount is the number of files referencing the object.
+	RefCount int64
+}
+
+// AcquireBlobInTransaction increments the reference count of the blob having the content and the encryption mode
+// in a transaction and returns the blob. It returns gorm.ErrRecordNotFound if no blob has the content.
+func AcquireBlobInTransaction(tx *gorm.DB, tenantID, sha256, encryptionMode string) (*Blob, error) {
+	// Blobs without references are being deleted and cannot be acquired.
+	res := tx.Model(&Blob{}).
+		Where("tenant_id = ? AND sha256 = ? AND encryption_mode = ? AND ref_count > 0", tenantID, sha256, encryptionMode).
+		Update("ref_count", gorm.Expr("ref_count + 1"))
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	var b Blob
+	if err := tx.Where("tenant_id = ? AND sha256 = ? AND encryption_mode = ?", tenantID, sha256, encryptionMode).Take(&b).Error; err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// CreateBlobInTransaction creates a blob with a reference in a transaction. It returns false
+// if a blob having the same content has been created concurrently.
+func CreateBlobInTransaction(tx *gorm.DB, b *Blob) (bool, error) {
+	b.RefCount = 1
+	res := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "sha256"}, {Name: "encryption_mode"}},
+		DoNothing: true,
+	}).Create(b)
+	if err := res.Error; err != nil {
+		return false, err
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// ReleaseBlobInTransaction decrements the reference count of the blob of the object in a transaction.
+// The blob is deleted when the last reference is released. It returns true if the object is no longer
+// referenced, and gorm.ErrRecordNotFound if the object is not a blob.
+func ReleaseBlobInTransaction(tx *gorm.DB, objectStorePath string) (bool, error) {
+	res := tx.Model(&Blob{}).
+		Where("object_store_path = ? AND ref_count > 0", objectStorePath).
+		Update("ref_count", gorm.Expr("ref_count - 1"))
+	if err := res.Error; err != nil {
+		return false, err
+	}
+	if res.RowsAffected == 0 {
+		return false, gorm.ErrRecordNotFound
+	}
+	res = tx.Unscoped().Where("object_store_path = ? AND ref_count = 0", objectStorePath).Delete(&Blob{})
+	if err := res.Error; err != nil {
+		return false, err
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// GetBlobByObjectStorePath returns a blob by object store path.
+func (s *S) GetBlobByObjectStorePath(path string) (*Blob, error) {
+	var b Blob
+	if err := s.db.Where("object_store_path = ?", path).Take(&b).Error; err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
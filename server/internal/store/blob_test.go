@@ -0,0 +1,60 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestBlob(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	_, err := AcquireBlobInTransaction(st.db, "tid0", "sha0", "")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	created, err := CreateBlobInTransaction(st.db, &Blob{
+		TenantID:        "tid0",
+		SHA256:          "sha0",
+		ObjectStorePath: "path0",
+	})
+	assert.NoError(t, err)
+	assert.True(t, created)
+
+	// A blob having the same content already exists.
+	created, err = CreateBlobInTransaction(st.db, &Blob{
+		TenantID:        "tid0",
+		SHA256:          "sha0",
+		ObjectStorePath: "path1",
+	})
+	assert.NoError(t, err)
+	assert.False(t, created)
+
+	// Blobs are not shared across tenants.
+	_, err = AcquireBlobInTransaction(st.db, "tid1", "sha0", "")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	// Blobs are not shared across encryption modes.
+	_, err = AcquireBlobInTransaction(st.db, "tid0", "sha0", "sse-kms")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	b, err := AcquireBlobInTransaction(st.db, "tid0", "sha0", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "path0", b.ObjectStorePath)
+	assert.Equal(t, int64(2), b.RefCount)
+
+	deleted, err := ReleaseBlobInTransaction(st.db, "path0")
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+	deleted, err = ReleaseBlobInTransaction(st.db, "path0")
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+
+	_, err = st.GetBlobByObjectStorePath("path0")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	_, err = ReleaseBlobInTransaction(st.db, "path0")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	_, err = AcquireBlobInTransaction(st.db, "tid0", "sha0", "")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+}
@@ -1,9 +1,47 @@
 package store
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"gorm.io/gorm"
 )
 
+// ObjectVerificationStatus is the status of the verification of an object registered with CreateFileFromObjectPath.
+type ObjectVerificationStatus string
+
+const (
+	// ObjectVerificationStatusVerified is the status of an object whose existence and attributes have been verified.
+	ObjectVerificationStatusVerified ObjectVerificationStatus = "verified"
+	// ObjectVerificationStatusPending is the status of an object that could not be verified by the control plane.
+	// The object is verified later by a worker cluster.
+	ObjectVerificationStatusPending ObjectVerificationStatus = "pending"
+	// ObjectVerificationStatusNotFound is the status of an object that has been found missing by a worker cluster.
+	ObjectVerificationStatusNotFound ObjectVerificationStatus = "not_found"
+)
+
+// ValidationStatus is the status of the validation of the content of a file.
+type ValidationStatus string
+
+const (
+	// ValidationStatusValid is the status of a file whose content has been validated.
+	ValidationStatusValid ValidationStatus = "valid"
+)
+
+// FileStatus is the status of a file.
+type FileStatus string
+
+const (
+	// FileStatusUploaded is the status of a file that has been created, but is not ready for use yet.
+	FileStatusUploaded FileStatus = "uploaded"
+	// FileStatusProcessed is the status of a file that is ready for use.
+	FileStatusProcessed FileStatus = "processed"
+	// FileStatusError is the status of a file that cannot be used.
+	FileStatusError FileStatus = "error"
+)
+
 // File represents a file.
 type File struct {
 	gorm.Model
@@ -19,7 +57,42 @@ type File struct {
 
 	Bytes int64
 
-	ObjectStorePath string
+	ObjectStorePath string `gorm:"index"`
+
+	// ETag is the entity tag of the object. It is empty if the file is created from an object path
+	// that has not been verified.
+	ETag string
+
+	// ObjectVerificationStatus is empty for files whose objects are uploaded to file-manager.
+	ObjectVerificationStatus ObjectVerificationStatus `gorm:"index"`
+	// ObjectLastModifiedAt is the last modified time of an object registered with CreateFileFromObjectPath.
+	// It is the zero time if the object has not been verified.
+	ObjectLastModifiedAt time.Time
+
+	// ValidationStatus is empty if the content has not been validated.
+	ValidationStatus ValidationStatus
+	// Lines is the number of lines of a JSONL file. It is set only when the content has been validated.
+	Lines int64
+
+	// Status defaults to processed so that files created before the status was introduced are usable.
+	Status FileStatus `gorm:"index;default:processed"`
+	// StatusDetails is the reason why the file is in the error status.
+	StatusDetails string
+
+	// ExpiresAt is the time when the file expires. It is the zero time if the file does not expire.
+	ExpiresAt time.Time `gorm:"index"`
+
+	// SHA256 and MD5 are the hex-encoded checksums of the content. They are empty if not computed.
+	SHA256 string
+	MD5    string
+
+	// EncryptionMode is the server-side encryption mode of the object. It is empty if the object
+	// is not encrypted by file-manager.
+	EncryptionMode string
+
+	// Version is incremented every time the file is updated by users. It is used for optimistic
+	// concurrency control.
+	Version int64 `gorm:"default:1"`
 }
 
 // FileSpec is a spec of the file
@@ -34,10 +107,39 @@ type FileSpec struct {
 	Bytes    int64
 
 	ObjectStorePath string
+	ETag            string
+
+	ObjectVerificationStatus ObjectVerificationStatus
+	ObjectLastModifiedAt     time.Time
+
+	ValidationStatus ValidationStatus
+	Lines            int64
+
+	// Status defaults to processed if empty.
+	Status FileStatus
+
+	ExpiresAt time.Time
+
+	SHA256 string
+	MD5    string
+
+	EncryptionMode string
+
+	// Metadata is the user-defined metadata of the file.
+	Metadata map[string]string
 }
 
 // CreateFile creates a file.
 func (s *S) CreateFile(spec FileSpec) (*File, error) {
+	return CreateFileInTransaction(s.db, spec)
+}
+
+// CreateFileInTransaction creates a file in a transaction.
+func CreateFileInTransaction(tx *gorm.DB, spec FileSpec) (*File, error) {
+	st := spec.Status
+	if st == "" {
+		st = FileStatusProcessed
+	}
 	f := &File{
 		FileID:         spec.FileID,
 		TenantID:       spec.TenantID,
@@ -49,8 +151,29 @@ func (s *S) CreateFile(spec FileSpec) (*File, error) {
 		Bytes:    spec.Bytes,
 
 		ObjectStorePath: spec.ObjectStorePath,
+		ETag:            spec.ETag,
+
+		ObjectVerificationStatus: spec.ObjectVerificationStatus,
+		ObjectLastModifiedAt:     spec.ObjectLastModifiedAt,
+
+		ValidationStatus: spec.ValidationStatus,
+		Lines:            spec.Lines,
+
+		Status: st,
+
+		ExpiresAt: spec.ExpiresAt,
+
+		SHA256: spec.SHA256,
+		MD5:    spec.MD5,
+
+		EncryptionMode: spec.EncryptionMode,
+
+		Version: 1,
+	}
+	if err := tx.Create(f).Error; err != nil {
+		return nil, err
 	}
-	if err := s.db.Create(f).Error; err != nil {
+	if err := createFileMetadataInTransaction(tx, f.FileID, spec.Metadata); err != nil {
 		return nil, err
 	}
 	return f, nil
@@ -83,6 +206,15 @@ func (s *S) GetFileByFileID(fileID string) (*File, error) {
 	return &f, nil
 }
 
+// GetFileByObjectStorePath returns a file by object store path.
+func (s *S) GetFileByObjectStorePath(path string) (*File, error) {
+	var f File
+	if err := s.db.Where("object_store_path = ?", path).Take(&f).Error; err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
 // GetFileByFileIDAndProjectID returns a file by file ID and project ID.
 func (s *S) GetFileByFileIDAndProjectID(fileID, projectID string) (*File, error) {
 	var f File
@@ -110,62 +242,296 @@ func (s *S) ListFilesByProjectIDAndPurpose(projectID, purpose string) ([]*File,
 	return fs, nil
 }
 
-// ListFilesByProjectIDWithPagination lists files with pagination.
-func (s *S) ListFilesByProjectIDWithPagination(projectID string, afterID uint, limit int, order string) ([]*File, bool, error) {
-	var fs []*File
-	query := s.db.Where("project_id = ?", projectID)
-	if afterID > 0 {
-		if order == "asc" {
-			query = query.Where("id > ?", afterID)
-		} else {
-			query = query.Where("id < ?", afterID)
+// FileSortKey is the key to sort files by.
+type FileSortKey string
+
+const (
+	// FileSortKeyCreatedAt sorts files by their creation time.
+	FileSortKeyCreatedAt FileSortKey = "created_at"
+	// FileSortKeyFilename sorts files by their filenames.
+	FileSortKeyFilename FileSortKey = "filename"
+	// FileSortKeyBytes sorts files by their sizes.
+	FileSortKeyBytes FileSortKey = "bytes"
+)
+
+// FileFilter is a filter of files in a project. Conditions with zero values are ignored.
+type FileFilter struct {
+	ProjectID string
+
+	Purpose string
+	Status  FileStatus
+
+	FilenamePrefix string
+	// FilenameContains matches files whose filenames contain the substring.
+	FilenameContains string
+
+	// CreatedAtStart and CreatedAtEnd match files created in [CreatedAtStart, CreatedAtEnd).
+	CreatedAtStart time.Time
+	CreatedAtEnd   time.Time
+
+	// MinBytes and MaxBytes match files whose sizes are in [MinBytes, MaxBytes].
+	MinBytes int64
+	MaxBytes int64
+
+	ObjectStorePathPrefix string
+
+	// Metadata is the requirements on the metadata. Files must satisfy all of them.
+	Metadata []MetadataRequirement
+}
+
+// ListFilesOptions are the options of ListFiles.
+type ListFilesOptions struct {
+	Filter FileFilter
+
+	// SortKey defaults to FileSortKeyCreatedAt.
+	SortKey FileSortKey
+	// Order is either "asc" or "desc". It defaults to "desc".
+	Order string
+
+	// After is the last file of the previous page. Only the ID and the field of the sort key are used.
+	After *File
+	Limit int
+}
+
+// ListFiles lists files matching the filter in the order of the sort key. Files having the same
+// value of the sort key are ordered by ID. It returns true if there are more files.
+func (s *S) ListFiles(opts ListFilesOptions) ([]*File, bool, error) {
+	query := applyFileFilter(s.db, opts.Filter)
+
+	cmp, dir := "<", "DESC"
+	if opts.Order == "asc" {
+		cmp, dir = ">", "ASC"
+	}
+	// col is empty when files are sorted by creation time. Files are created in the order of IDs,
+	// so they are sorted by ID instead so that the index of the primary key is used.
+	var col string
+	var afterValue interface{}
+	switch opts.SortKey {
+	case FileSortKeyFilename:
+		col = "filename"
+		if opts.After != nil {
+			afterValue = opts.After.Filename
+		}
+	case FileSortKeyBytes:
+		col = "bytes"
+		if opts.After != nil {
+			afterValue = opts.After.Bytes
 		}
 	}
 
-	if order == "asc" {
-		query = query.Order("id ASC")
-	} else {
-		query = query.Order("id DESC")
+	if a := opts.After; a != nil {
+		if col == "" {
+			query = query.Where("id "+cmp+" ?", a.ID)
+		} else {
+			query = query.Where(
+				fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", col, cmp, col, cmp),
+				afterValue, afterValue, a.ID,
+			)
+		}
 	}
-	query = query.Limit(limit + 1)
-	if err := query.Find(&fs).Error; err != nil {
-		return nil, false, err
+	if col != "" {
+		query = query.Order(col + " " + dir)
 	}
+	query = query.Order("id " + dir)
 
-	hasMore := len(fs) > limit
+	var fs []*File
+	if err := query.Limit(opts.Limit + 1).Find(&fs).Error; err != nil {
+		return nil, false, err
+	}
+	hasMore := len(fs) > opts.Limit
 	if hasMore {
-		fs = fs[:limit]
+		fs = fs[:opts.Limit]
 	}
 	return fs, hasMore, nil
 }
 
-// ListFilesByProjectIDAndPurposeWithPagination lists files with pagination.
-func (s *S) ListFilesByProjectIDAndPurposeWithPagination(projectID, purpose string, afterID uint, limit int, order string) ([]*File, bool, error) {
+// CountFiles returns the number of files matching the filter.
+func (s *S) CountFiles(filter FileFilter) (int64, error) {
+	var count int64
+	if err := applyFileFilter(s.db.Model(&File{}), filter).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func applyFileFilter(query *gorm.DB, f FileFilter) *gorm.DB {
+	query = query.Where("project_id = ?", f.ProjectID)
+	if f.Purpose != "" {
+		query = query.Where("purpose = ?", f.Purpose)
+	}
+	if f.Status != "" {
+		query = query.Where("status = ?", f.Status)
+	}
+	if f.FilenamePrefix != "" {
+		query = query.Where(`filename LIKE ? ESCAPE '\'`, escapeLike(f.FilenamePrefix)+"%")
+	}
+	if f.FilenameContains != "" {
+		query = query.Where(`filename LIKE ? ESCAPE '\'`, "%"+escapeLike(f.FilenameContains)+"%")
+	}
+	if !f.CreatedAtStart.IsZero() {
+		query = query.Where("created_at >= ?", f.CreatedAtStart)
+	}
+	if !f.CreatedAtEnd.IsZero() {
+		query = query.Where("created_at < ?", f.CreatedAtEnd)
+	}
+	if f.MinBytes > 0 {
+		query = query.Where("bytes >= ?", f.MinBytes)
+	}
+	if f.MaxBytes > 0 {
+		query = query.Where("bytes <= ?", f.MaxBytes)
+	}
+	if f.ObjectStorePathPrefix != "" {
+		query = query.Where(`object_store_path LIKE ? ESCAPE '\'`, escapeLike(f.ObjectStorePathPrefix)+"%")
+	}
+	for _, r := range f.Metadata {
+		query = applyMetadataRequirement(query, r)
+	}
+	return query
+}
+
+// ListFilesPendingObjectVerification lists files whose objects are pending verification in the tenant.
+func (s *S) ListFilesPendingObjectVerification(tenantID string, limit int) ([]*File, error) {
 	var fs []*File
-	query := s.db.Where("project_id = ? AND purpose = ?", projectID, purpose)
-	if afterID > 0 {
-		if order == "asc" {
-			query = query.Where("id > ?", afterID)
-		} else {
-			query = query.Where("id < ?", afterID)
-		}
+	if err := s.db.Where("tenant_id = ? AND object_verification_status = ?", tenantID, ObjectVerificationStatusPending).
+		Order("id").Limit(limit).Find(&fs).Error; err != nil {
+		return nil, err
 	}
+	return fs, nil
+}
 
-	if order == "asc" {
-		query = query.Order("id ASC")
-	} else {
-		query = query.Order("id DESC")
+// UpdateFileObjectVerification updates the object verification status, the object attributes and the status
+// of a file pending verification. It returns gorm.ErrRecordNotFound if the file is not pending verification.
+func (s *S) UpdateFileObjectVerification(
+	fileID,
+	tenantID string,
+	status ObjectVerificationStatus,
+	bytes int64,
+	etag string,
+	lastModifiedAt time.Time,
+	fileStatus FileStatus,
+	statusDetails string,
+) error {
+	res := s.db.Model(&File{}).
+		Where("file_id = ? AND tenant_id = ? AND object_verification_status = ?", fileID, tenantID, ObjectVerificationStatusPending).
+		Updates(map[string]interface{}{
+			"object_verification_status": status,
+			"bytes":                      bytes,
+			"e_tag":                      etag,
+			"object_last_modified_at":    lastModifiedAt,
+			"status":                     fileStatus,
+			"status_details":             statusDetails,
+		})
+	if err := res.Error; err != nil {
+		return err
 	}
-	query = query.Limit(limit + 1)
-	if err := query.Find(&fs).Error; err != nil {
-		return nil, false, err
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
+	return nil
+}
 
-	hasMore := len(fs) > limit
-	if hasMore {
-		fs = fs[:limit]
+// ListExpiredFiles lists files that have expired at the given time.
+func (s *S) ListExpiredFiles(now time.Time, limit int) ([]*File, error) {
+	var fs []*File
+	if err := s.db.Where("expires_at > ? AND expires_at <= ?", time.Time{}, now).
+		Order("expires_at").Limit(limit).Find(&fs).Error; err != nil {
+		return nil, err
 	}
-	return fs, hasMore, nil
+	return fs, nil
+}
+
+// ListFilesAfterID lists files whose IDs are greater than the given ID in all tenants in the ascending order of IDs.
+func (s *S) ListFilesAfterID(afterID uint, limit int) ([]*File, error) {
+	var fs []*File
+	if err := s.db.Where("id > ?", afterID).Order("id").Limit(limit).Find(&fs).Error; err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// UpdateFileChecksums sets the checksums of a file.
+func (s *S) UpdateFileChecksums(fileID, sha256, md5 string) error {
+	return s.updateFile(fileID, map[string]interface{}{
+		"sha256": sha256,
+		"md5":    md5,
+	})
+}
+
+// ErrConcurrentUpdate is returned when a file has been updated or deleted since it was read.
+var ErrConcurrentUpdate = errors.New("concurrent update")
+
+// FileUpdate is an update of a file by users. Nil fields are not updated.
+type FileUpdate struct {
+	Filename *string
+	Purpose  *string
+
+	// ValidationStatus and Lines are the result of the validation of the content for the purpose.
+	// They are updated together with the purpose.
+	ValidationStatus ValidationStatus
+	Lines            int64
+
+	// Metadata replaces the metadata of the file if not nil.
+	Metadata map[string]string
+}
+
+// UpdateFileWithVersion updates a file if its version is the given version, and increments the version.
+// It returns ErrConcurrentUpdate if the version has changed or the file has been deleted.
+func (s *S) UpdateFileWithVersion(fileID string, version int64, u FileUpdate) error {
+	updates := map[string]interface{}{
+		"version": gorm.Expr("version + 1"),
+	}
+	if u.Filename != nil {
+		updates["filename"] = *u.Filename
+	}
+	if u.Purpose != nil {
+		updates["purpose"] = *u.Purpose
+		updates["validation_status"] = u.ValidationStatus
+		updates["lines"] = u.Lines
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&File{}).Where("file_id = ? AND version = ?", fileID, version).Updates(updates)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if res.RowsAffected == 0 {
+			return ErrConcurrentUpdate
+		}
+		if u.Metadata != nil {
+			return ReplaceFileMetadataInTransaction(tx, fileID, u.Metadata)
+		}
+		return nil
+	})
+}
+
+// UpdateFileStatus updates the status of a file.
+func (s *S) UpdateFileStatus(fileID string, status FileStatus, statusDetails string) error {
+	return s.updateFile(fileID, map[string]interface{}{
+		"status":         status,
+		"status_details": statusDetails,
+	})
+}
+
+func (s *S) updateFile(fileID string, updates map[string]interface{}) error {
+	res := s.db.Model(&File{}).Where("file_id = ?", fileID).Updates(updates)
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListReferencedObjectStorePaths returns the paths referenced by files among the given object store paths.
+func (s *S) ListReferencedObjectStorePaths(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	var referenced []string
+	if err := s.db.Model(&File{}).Distinct("object_store_path").Where("object_store_path IN ?", paths).Pluck("object_store_path", &referenced).Error; err != nil {
+		return nil, err
+	}
+	return referenced, nil
 }
 
 // CountFilesByProjectID counts files by project ID.
@@ -177,14 +543,47 @@ func (s *S) CountFilesByProjectID(projectID string) (int64, error) {
 	return count, nil
 }
 
+// FileStats is the number and the total size of files having a purpose and a status.
+type FileStats struct {
+	Purpose string
+	Status  FileStatus
+	Files   int64
+	Bytes   int64
+}
+
+// ListFileStatsByProjectID returns the number and the total size of files in the project
+// grouped by purpose and status.
+func (s *S) ListFileStatsByProjectID(projectID string) ([]*FileStats, error) {
+	var stats []*FileStats
+	if err := s.db.Model(&File{}).
+		Select("purpose, status, COUNT(*) AS files, COALESCE(SUM(bytes), 0) AS bytes").
+		Where("project_id = ?", projectID).
+		Group("purpose, status").
+		Order("purpose, status").
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
 // DeleteFile deletes a file by file ID and project ID.
 func (s *S) DeleteFile(fileID, projectID string) error {
-	res := s.db.Unscoped().Where("file_id = ? AND project_id = ?", fileID, projectID).Delete(&File{})
+	return DeleteFileInTransaction(s.db, fileID, projectID)
+}
+
+// DeleteFileInTransaction deletes a file by file ID and project ID in a transaction.
+func DeleteFileInTransaction(tx *gorm.DB, fileID, projectID string) error {
+	res := tx.Unscoped().Where("file_id = ? AND project_id = ?", fileID, projectID).Delete(&File{})
 	if err := res.Error; err != nil {
 		return err
 	}
 	if res.RowsAffected == 0 {
 		return gorm.ErrRecordNotFound
 	}
-	return nil
+	return deleteFileMetadataInTransaction(tx, fileID)
+}
+
+// escapeLike escapes the special characters of the LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"gorm.io/gorm"
+)
+
+// FileMetadata is a key-value pair of the user-defined metadata of a file.
+type FileMetadata struct {
+	gorm.Model
+
+	FileID string `gorm:"uniqueIndex:idx_file_metadata_file_id_key"`
+	Key    string `gorm:"uniqueIndex:idx_file_metadata_file_id_key;index:idx_file_metadata_key_value"`
+	Value  string `gorm:"index:idx_file_metadata_key_value"`
+}
+
+// MetadataOperator is the operator of a requirement on the metadata of files.
+type MetadataOperator string
+
+const (
+	// MetadataOperatorEquals matches files having the key with the value.
+	MetadataOperatorEquals MetadataOperator = "="
+	// MetadataOperatorNotEquals matches files not having the key with the value, including files without the key.
+	MetadataOperatorNotEquals MetadataOperator = "!="
+	// MetadataOperatorExists matches files having the key.
+	MetadataOperatorExists MetadataOperator = "exists"
+	// MetadataOperatorDoesNotExist matches files not having the key.
+	MetadataOperatorDoesNotExist MetadataOperator = "!"
+)
+
+// MetadataRequirement is a requirement on the metadata of files.
+type MetadataRequirement struct {
+	Key      string
+	Operator MetadataOperator
+	// Value is used only with MetadataOperatorEquals and MetadataOperatorNotEquals.
+	Value string
+}
+
+// GetFileMetadata returns the metadata of a file. It returns an empty map if the file has no metadata.
+func (s *S) GetFileMetadata(fileID string) (map[string]string, error) {
+	mds, err := s.ListFileMetadata([]string{fileID})
+	if err != nil {
+		return nil, err
+	}
+	if md, ok := mds[fileID]; ok {
+		return md, nil
+	}
+	return map[string]string{}, nil
+}
+
+// ListFileMetadata returns the metadata of the files keyed by file IDs. Files without metadata are not included.
+func (s *S) ListFileMetadata(fileIDs []string) (map[string]map[string]string, error) {
+	mds := map[string]map[string]string{}
+	if len(fileIDs) == 0 {
+		return mds, nil
+	}
+	var ms []*FileMetadata
+	if err := s.db.Where("file_id IN ?", fileIDs).Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	for _, m := range ms {
+		md, ok := mds[m.FileID]
+		if !ok {
+			md = map[string]string{}
+			mds[m.FileID] = md
+		}
+		md[m.Key] = m.Value
+	}
+	return mds, nil
+}
+
+// ReplaceFileMetadataInTransaction replaces the metadata of a file in a transaction.
+func ReplaceFileMetadataInTransaction(tx *gorm.DB, fileID string, md map[string]string) error {
+	if err := deleteFileMetadataInTransaction(tx, fileID); err != nil {
+		return err
+	}
+	return createFileMetadataInTransaction(tx, fileID, md)
+}
+
+func createFileMetadataInTransaction(tx *gorm.DB, fileID string, md map[string]string) error {
+	if len(md) == 0 {
+		return nil
+	}
+	var ms []*FileMetadata
+	for k, v := range md {
+		ms = append(ms, &FileMetadata{
+			FileID: fileID,
+			Key:    k,
+			Value:  v,
+		})
+	}
+	return tx.Create(ms).Error
+}
+
+func deleteFileMetadataInTransaction(tx *gorm.DB, fileID string) error {
+	return tx.Unscoped().Where("file_id = ?", fileID).Delete(&FileMetadata{}).Error
+}
+
+// applyMetadataRequirement adds the condition of the requirement on the metadata to the query of files.
+func applyMetadataRequirement(query *gorm.DB, r MetadataRequirement) *gorm.DB {
+	sub := query.Session(&gorm.Session{NewDB: true}).Model(&FileMetadata{}).Select("file_id")
+	switch r.Operator {
+	case MetadataOperatorEquals:
+		return query.Where("file_id IN (?)", sub.Where("key = ? AND value = ?", r.Key, r.Value))
+	case MetadataOperatorNotEquals:
+		return query.Where("file_id NOT IN (?)", sub.Where("key = ? AND value = ?", r.Key, r.Value))
+	case MetadataOperatorExists:
+		return query.Where("file_id IN (?)", sub.Where("key = ?", r.Key))
+	case MetadataOperatorDoesNotExist:
+		return query.Where("file_id NOT IN (?)", sub.Where("key = ?", r.Key))
+	}
+	// Match no files with an unknown operator.
+	return query.Where("1 = 0")
+}
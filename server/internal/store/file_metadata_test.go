@@ -0,0 +1,113 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMetadata(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	const projectID = "pid0"
+	for i, md := range []map[string]string{
+		{"team": "ml", "source": "web"},
+		{"team": "ml"},
+		{"team": "infra", "source": "web"},
+		nil,
+	} {
+		_, err := st.CreateFile(FileSpec{
+			FileID:    fmt.Sprintf("f%d", i),
+			ProjectID: projectID,
+			Metadata:  md,
+		})
+		assert.NoError(t, err)
+	}
+
+	md, err := st.GetFileMetadata("f0")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "ml", "source": "web"}, md)
+
+	md, err = st.GetFileMetadata("f3")
+	assert.NoError(t, err)
+	assert.Empty(t, md)
+
+	mds, err := st.ListFileMetadata([]string{"f1", "f2", "f3"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"f1": {"team": "ml"},
+		"f2": {"team": "infra", "source": "web"},
+	}, mds)
+
+	tcs := []struct {
+		name string
+		reqs []MetadataRequirement
+		want []string
+	}{
+		{
+			name: "equals",
+			reqs: []MetadataRequirement{{Key: "team", Operator: MetadataOperatorEquals, Value: "ml"}},
+			want: []string{"f1", "f0"},
+		},
+		{
+			name: "not equals",
+			reqs: []MetadataRequirement{{Key: "team", Operator: MetadataOperatorNotEquals, Value: "ml"}},
+			want: []string{"f3", "f2"},
+		},
+		{
+			name: "exists",
+			reqs: []MetadataRequirement{{Key: "source", Operator: MetadataOperatorExists}},
+			want: []string{"f2", "f0"},
+		},
+		{
+			name: "does not exist",
+			reqs: []MetadataRequirement{{Key: "source", Operator: MetadataOperatorDoesNotExist}},
+			want: []string{"f3", "f1"},
+		},
+		{
+			name: "multiple requirements",
+			reqs: []MetadataRequirement{
+				{Key: "team", Operator: MetadataOperatorEquals, Value: "ml"},
+				{Key: "source", Operator: MetadataOperatorExists},
+			},
+			want: []string{"f0"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := FileFilter{
+				ProjectID: projectID,
+				Metadata:  tc.reqs,
+			}
+			fs, _, err := st.ListFiles(ListFilesOptions{
+				Filter: filter,
+				Limit:  10,
+			})
+			assert.NoError(t, err)
+			var got []string
+			for _, f := range fs {
+				got = append(got, f.FileID)
+			}
+			assert.Equal(t, tc.want, got)
+
+			count, err := st.CountFiles(filter)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tc.want)), count)
+		})
+	}
+
+	err = ReplaceFileMetadataInTransaction(st.db, "f0", map[string]string{"team": "infra"})
+	assert.NoError(t, err)
+	md, err = st.GetFileMetadata("f0")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "infra"}, md)
+
+	// The metadata is deleted with the file.
+	err = st.DeleteFile("f0", projectID)
+	assert.NoError(t, err)
+	md, err = st.GetFileMetadata("f0")
+	assert.NoError(t, err)
+	assert.Empty(t, md)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -83,58 +84,89 @@ func TestFilePagination(t *testing.T) {
 	tcs := []struct {
 		name           string
 		purpose        string
+		status         FileStatus
 		cursor         uint
 		limit          int
 		order          string
 		wantFiles      []string
 		wantHasMore    bool
 		wantTotalCount int64
+		// wantFilteredCount is the number of files matching the filters.
+		wantFilteredCount int64
 	}{
 		{
-			name:           "descending order first page",
-			cursor:         0,
-			limit:          3,
-			order:          "desc",
-			wantFiles:      []string{"f4", "f3", "f2"},
-			wantHasMore:    true,
-			wantTotalCount: 5,
-		},
-		{
-			name:           "ascending order first page",
-			cursor:         0,
-			limit:          3,
-			order:          "asc",
-			wantFiles:      []string{"f0", "f1", "f2"},
-			wantHasMore:    true,
-			wantTotalCount: 5,
-		},
-		{
-			name:           "descending order with cursor",
-			cursor:         2,
-			limit:          3,
-			order:          "desc",
-			wantFiles:      []string{"f0"},
-			wantHasMore:    false,
-			wantTotalCount: 5,
-		},
-		{
-			name:           "ascending order with cursor",
-			cursor:         2,
-			limit:          3,
-			order:          "asc",
-			wantFiles:      []string{"f2", "f3", "f4"},
-			wantHasMore:    false,
-			wantTotalCount: 5,
-		},
-		{
-			name:           "with purpose filter",
-			purpose:        purpose,
-			cursor:         0,
-			limit:          3,
-			order:          "desc",
-			wantFiles:      []string{"f4", "f3", "f2"},
-			wantHasMore:    true,
-			wantTotalCount: 5,
+			name:              "descending order first page",
+			cursor:            0,
+			limit:             3,
+			order:             "desc",
+			wantFiles:         []string{"f4", "f3", "f2"},
+			wantHasMore:       true,
+			wantTotalCount:    5,
+			wantFilteredCount: 5,
+		},
+		{
+			name:              "ascending order first page",
+			cursor:            0,
+			limit:             3,
+			order:             "asc",
+			wantFiles:         []string{"f0", "f1", "f2"},
+			wantHasMore:       true,
+			wantTotalCount:    5,
+			wantFilteredCount: 5,
+		},
+		{
+			name:              "descending order with cursor",
+			cursor:            2,
+			limit:             3,
+			order:             "desc",
+			wantFiles:         []string{"f0"},
+			wantHasMore:       false,
+			wantTotalCount:    5,
+			wantFilteredCount: 5,
+		},
+		{
+			name:              "ascending order with cursor",
+			cursor:            2,
+			limit:             3,
+			order:             "asc",
+			wantFiles:         []string{"f2", "f3", "f4"},
+			wantHasMore:       false,
+			wantTotalCount:    5,
+			wantFilteredCount: 5,
+		},
+		{
+			name:              "with purpose filter",
+			purpose:           purpose,
+			cursor:            0,
+			limit:             3,
+			order:             "desc",
+			wantFiles:         []string{"f4", "f3", "f2"},
+			wantHasMore:       true,
+			wantTotalCount:    5,
+			wantFilteredCount: 5,
+		},
+		{
+			name:              "with status filter",
+			status:            FileStatusUploaded,
+			cursor:            0,
+			limit:             3,
+			order:             "desc",
+			wantFiles:         []string{"f3", "f1"},
+			wantHasMore:       false,
+			wantTotalCount:    5,
+			wantFilteredCount: 2,
+		},
+		{
+			name:              "with purpose and status filters",
+			purpose:           purpose,
+			status:            FileStatusProcessed,
+			cursor:            0,
+			limit:             2,
+			order:             "asc",
+			wantFiles:         []string{"f0", "f2"},
+			wantHasMore:       true,
+			wantTotalCount:    5,
+			wantFilteredCount: 3,
 		},
 	}
 
@@ -144,6 +176,10 @@ func TestFilePagination(t *testing.T) {
 			defer tearDown()
 
 			for i := 0; i < 5; i++ {
+				status := FileStatusProcessed
+				if i%2 == 1 {
+					status = FileStatusUploaded
+				}
 				_, err := st.CreateFile(FileSpec{
 					FileID:         fmt.Sprintf("f%d", i),
 					TenantID:       fmt.Sprintf("tid%d", i),
@@ -151,22 +187,26 @@ func TestFilePagination(t *testing.T) {
 					ProjectID:      projectID,
 					Filename:       fmt.Sprintf("filename%d", i),
 					Purpose:        purpose,
+					Status:         status,
 				})
 				assert.NoError(t, err)
 			}
 
-			var (
-				files   []*File
-				hasMore bool
-				err     error
-			)
-
-			if tc.purpose != "" {
-				files, hasMore, err = st.ListFilesByProjectIDAndPurposeWithPagination(projectID, tc.purpose, tc.cursor, tc.limit, tc.order)
-			} else {
-				files, hasMore, err = st.ListFilesByProjectIDWithPagination(projectID, tc.cursor, tc.limit, tc.order)
+			var after *File
+			if tc.cursor > 0 {
+				after = &File{Model: gorm.Model{ID: tc.cursor}}
 			}
-
+			filter := FileFilter{
+				ProjectID: projectID,
+				Purpose:   tc.purpose,
+				Status:    tc.status,
+			}
+			files, hasMore, err := st.ListFiles(ListFilesOptions{
+				Filter: filter,
+				Order:  tc.order,
+				After:  after,
+				Limit:  tc.limit,
+			})
 			assert.NoError(t, err)
 			assert.Equal(t, tc.wantHasMore, hasMore)
 			assert.Len(t, files, len(tc.wantFiles))
@@ -178,6 +218,318 @@ func TestFilePagination(t *testing.T) {
 			count, err := st.CountFilesByProjectID(projectID)
 			assert.NoError(t, err)
 			assert.Equal(t, tc.wantTotalCount, count)
+
+			count, err = st.CountFiles(filter)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantFilteredCount, count)
+		})
+	}
+}
+
+func TestListFilesWithFilterAndSortKey(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	const projectID = "pid0"
+	base := time.Unix(1700000000, 0).UTC()
+	for i, spec := range []struct {
+		filename string
+		bytes    int64
+		path     string
+	}{
+		{filename: "train_b.jsonl", bytes: 30, path: "s3://bucket/a/f0"},
+		{filename: "train_a.jsonl", bytes: 10, path: "s3://bucket/b/f1"},
+		{filename: "eval_a.jsonl", bytes: 20, path: "s3://bucket/a/f2"},
+		{filename: "train_c.jsonl", bytes: 20, path: "s3://bucket/a_b/f3"},
+		{filename: "100%_train.jsonl", bytes: 40, path: "s3://bucket/a%/f4"},
+	} {
+		f, err := st.CreateFile(FileSpec{
+			FileID:          fmt.Sprintf("f%d", i),
+			ProjectID:       projectID,
+			Filename:        spec.filename,
+			Bytes:           spec.bytes,
+			ObjectStorePath: spec.path,
+		})
+		assert.NoError(t, err)
+		err = st.db.Model(f).Update("created_at", base.Add(time.Duration(i)*time.Hour)).Error
+		assert.NoError(t, err)
+	}
+	_, err := st.CreateFile(FileSpec{
+		FileID:    "f5",
+		ProjectID: "pid1",
+		Filename:  "train_d.jsonl",
+	})
+	assert.NoError(t, err)
+
+	tcs := []struct {
+		name      string
+		filter    FileFilter
+		sortKey   FileSortKey
+		order     string
+		afterID   string
+		limit     int
+		want      []string
+		wantCount int64
+	}{
+		{
+			name:      "filename prefix",
+			filter:    FileFilter{FilenamePrefix: "train_"},
+			want:      []string{"f3", "f1", "f0"},
+			wantCount: 3,
+		},
+		{
+			name:      "filename contains",
+			filter:    FileFilter{FilenameContains: "_a"},
+			want:      []string{"f2", "f1"},
+			wantCount: 2,
+		},
+		{
+			name:      "filename contains a wildcard",
+			filter:    FileFilter{FilenameContains: "%_"},
+			want:      []string{"f4"},
+			wantCount: 1,
+		},
+		{
+			name: "created at range",
+			filter: FileFilter{
+				CreatedAtStart: base.Add(time.Hour),
+				CreatedAtEnd:   base.Add(3 * time.Hour),
+			},
+			want:      []string{"f2", "f1"},
+			wantCount: 2,
+		},
+		{
+			name:      "size range",
+			filter:    FileFilter{MinBytes: 20, MaxBytes: 30},
+			want:      []string{"f3", "f2", "f0"},
+			wantCount: 3,
+		},
+		{
+			name:      "object store path prefix",
+			filter:    FileFilter{ObjectStorePathPrefix: "s3://bucket/a/"},
+			want:      []string{"f2", "f0"},
+			wantCount: 2,
+		},
+		{
+			name:      "sort by filename",
+			sortKey:   FileSortKeyFilename,
+			order:     "asc",
+			want:      []string{"f4", "f2", "f1", "f0", "f3"},
+			wantCount: 5,
+		},
+		{
+			name:      "sort by bytes",
+			sortKey:   FileSortKeyBytes,
+			want:      []string{"f4", "f0", "f3", "f2", "f1"},
+			wantCount: 5,
+		},
+		{
+			name:      "sort by bytes with cursor",
+			sortKey:   FileSortKeyBytes,
+			afterID:   "f3",
+			limit:     2,
+			want:      []string{"f2", "f1"},
+			wantCount: 5,
+		},
+		{
+			name:      "sort by bytes ascending with cursor",
+			filter:    FileFilter{FilenamePrefix: "train_"},
+			sortKey:   FileSortKeyBytes,
+			order:     "asc",
+			afterID:   "f1",
+			want:      []string{"f3", "f0"},
+			wantCount: 3,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.filter.ProjectID = projectID
+			var after *File
+			if tc.afterID != "" {
+				after, err = st.GetFileByFileID(tc.afterID)
+				assert.NoError(t, err)
+			}
+			limit := tc.limit
+			if limit == 0 {
+				limit = 10
+			}
+			fs, _, err := st.ListFiles(ListFilesOptions{
+				Filter:  tc.filter,
+				SortKey: tc.sortKey,
+				Order:   tc.order,
+				After:   after,
+				Limit:   limit,
+			})
+			assert.NoError(t, err)
+			var got []string
+			for _, f := range fs {
+				got = append(got, f.FileID)
+			}
+			assert.Equal(t, tc.want, got)
+
+			count, err := st.CountFiles(tc.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantCount, count)
 		})
 	}
 }
+
+func TestUpdateFileObjectVerification(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	for i, status := range []ObjectVerificationStatus{ObjectVerificationStatusPending, ObjectVerificationStatusVerified} {
+		_, err := st.CreateFile(FileSpec{
+			FileID:                   fmt.Sprintf("f%d", i),
+			TenantID:                 "tid0",
+			ProjectID:                "pid0",
+			ObjectStorePath:          fmt.Sprintf("s3://bucket/f%d", i),
+			ObjectVerificationStatus: status,
+		})
+		assert.NoError(t, err)
+	}
+
+	fs, err := st.ListFilesPendingObjectVerification("tid0", 10)
+	assert.NoError(t, err)
+	assert.Len(t, fs, 1)
+	assert.Equal(t, "f0", fs[0].FileID)
+
+	fs, err = st.ListFilesPendingObjectVerification("tid1", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, fs)
+
+	// Files in other tenants and files not pending verification cannot be updated.
+	err = st.UpdateFileObjectVerification("f0", "tid1", ObjectVerificationStatusVerified, 10, "etag", time.Now(), FileStatusProcessed, "")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	err = st.UpdateFileObjectVerification("f1", "tid0", ObjectVerificationStatusVerified, 10, "etag", time.Now(), FileStatusProcessed, "")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	lastModified := time.Unix(1700000000, 0).UTC()
+	err = st.UpdateFileObjectVerification("f0", "tid0", ObjectVerificationStatusVerified, 10, "etag", lastModified, FileStatusProcessed, "")
+	assert.NoError(t, err)
+
+	f, err := st.GetFileByFileID("f0")
+	assert.NoError(t, err)
+	assert.Equal(t, ObjectVerificationStatusVerified, f.ObjectVerificationStatus)
+	assert.Equal(t, int64(10), f.Bytes)
+	assert.Equal(t, "etag", f.ETag)
+	assert.True(t, lastModified.Equal(f.ObjectLastModifiedAt))
+	assert.Equal(t, FileStatusProcessed, f.Status)
+
+	fs, err = st.ListFilesPendingObjectVerification("tid0", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, fs)
+}
+
+func TestUpdateFileWithVersion(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	f, err := st.CreateFile(FileSpec{
+		FileID:    "f0",
+		ProjectID: "pid0",
+		Filename:  "file0.txt",
+		Purpose:   "purpose0",
+		Metadata:  map[string]string{"team": "ml"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), f.Version)
+
+	filename := "file1.jsonl"
+	err = st.UpdateFileWithVersion("f0", 1, FileUpdate{Filename: &filename})
+	assert.NoError(t, err)
+	f, err = st.GetFileByFileID("f0")
+	assert.NoError(t, err)
+	assert.Equal(t, "file1.jsonl", f.Filename)
+	assert.Equal(t, "purpose0", f.Purpose)
+	assert.Equal(t, int64(2), f.Version)
+
+	// The version is stale.
+	purpose := "purpose1"
+	err = st.UpdateFileWithVersion("f0", 1, FileUpdate{Purpose: &purpose})
+	assert.True(t, errors.Is(err, ErrConcurrentUpdate))
+
+	err = st.UpdateFileWithVersion("f0", 2, FileUpdate{
+		Purpose:          &purpose,
+		ValidationStatus: ValidationStatusValid,
+		Lines:            3,
+		Metadata:         map[string]string{},
+	})
+	assert.NoError(t, err)
+	f, err = st.GetFileByFileID("f0")
+	assert.NoError(t, err)
+	assert.Equal(t, "purpose1", f.Purpose)
+	assert.Equal(t, ValidationStatusValid, f.ValidationStatus)
+	assert.Equal(t, int64(3), f.Lines)
+	assert.Equal(t, int64(3), f.Version)
+	md, err := st.GetFileMetadata("f0")
+	assert.NoError(t, err)
+	assert.Empty(t, md)
+
+	err = st.UpdateFileWithVersion("f1", 1, FileUpdate{Filename: &filename})
+	assert.True(t, errors.Is(err, ErrConcurrentUpdate))
+}
+
+func TestListFileStatsByProjectID(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	for i, spec := range []struct {
+		projectID string
+		purpose   string
+		status    FileStatus
+		bytes     int64
+	}{
+		{projectID: "pid0", purpose: "purpose0", status: FileStatusProcessed, bytes: 10},
+		{projectID: "pid0", purpose: "purpose0", status: FileStatusProcessed, bytes: 20},
+		{projectID: "pid0", purpose: "purpose0", status: FileStatusError, bytes: 30},
+		{projectID: "pid0", purpose: "purpose1", status: FileStatusProcessed, bytes: 40},
+		{projectID: "pid1", purpose: "purpose0", status: FileStatusProcessed, bytes: 50},
+	} {
+		_, err := st.CreateFile(FileSpec{
+			FileID:    fmt.Sprintf("f%d", i),
+			ProjectID: spec.projectID,
+			Purpose:   spec.purpose,
+			Status:    spec.status,
+			Bytes:     spec.bytes,
+		})
+		assert.NoError(t, err)
+	}
+	// Deleted files are not counted.
+	err := st.DeleteFile("f1", "pid0")
+	assert.NoError(t, err)
+
+	stats, err := st.ListFileStatsByProjectID("pid0")
+	assert.NoError(t, err)
+	assert.Equal(t, []*FileStats{
+		{Purpose: "purpose0", Status: FileStatusError, Files: 1, Bytes: 30},
+		{Purpose: "purpose0", Status: FileStatusProcessed, Files: 1, Bytes: 10},
+		{Purpose: "purpose1", Status: FileStatusProcessed, Files: 1, Bytes: 40},
+	}, stats)
+
+	stats, err = st.ListFileStatsByProjectID("pid2")
+	assert.NoError(t, err)
+	assert.Empty(t, stats)
+}
+
+func TestListReferencedObjectStorePaths(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	for i, path := range []string{"prefix/a", "prefix/a", "prefix/b"} {
+		_, err := st.CreateFile(FileSpec{
+			FileID:          fmt.Sprintf("f%d", i),
+			ProjectID:       "pid0",
+			ObjectStorePath: path,
+		})
+		assert.NoError(t, err)
+	}
+
+	paths, err := st.ListReferencedObjectStorePaths([]string{"prefix/a", "prefix/c"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prefix/a"}, paths)
+
+	paths, err = st.ListReferencedObjectStorePaths(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, paths)
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Lease is a named lease held by one server replica at a time. It is used to run a periodic
+// task in only one of the replicas.
+type Lease struct {
+	gorm.Model
+
+	Name string `gorm:"uniqueIndex"`
+	// Holder is the ID of the replica holding the lease.
+	Holder    string
+	ExpiresAt time.Time
+}
+
+// AcquireLease acquires or renews a lease until now + duration. It returns false if the lease is
+// held by another holder and has not expired.
+func (s *S) AcquireLease(name, holder string, now time.Time, duration time.Duration) (bool, error) {
+	expiresAt := now.Add(duration)
+	res := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoNothing: true,
+	}).Create(&Lease{Name: name, Holder: holder, ExpiresAt: expiresAt})
+	if err := res.Error; err != nil {
+		return false, err
+	}
+	if res.RowsAffected > 0 {
+		return true, nil
+	}
+
+	res = s.db.Model(&Lease{}).
+		Where("name = ? AND (holder = ? OR expires_at <= ?)", name, holder, now).
+		Updates(map[string]any{
+			"holder":     holder,
+			"expires_at": expiresAt,
+		})
+	if err := res.Error; err != nil {
+		return false, err
+	}
+	return res.RowsAffected > 0, nil
+}
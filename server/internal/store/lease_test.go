@@ -0,0 +1,36 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcquireLease(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	now := time.Now()
+	ok, err := st.AcquireLease("gc", "r0", now, time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// The lease is held by another holder.
+	ok, err = st.AcquireLease("gc", "r1", now.Add(time.Minute), time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// The holder renews the lease.
+	ok, err = st.AcquireLease("gc", "r0", now.Add(time.Minute), time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Another holder acquires the expired lease.
+	ok, err = st.AcquireLease("gc", "r1", now.Add(2*time.Hour), time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = st.AcquireLease("gc", "r0", now.Add(2*time.Hour), time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
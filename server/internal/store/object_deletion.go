@@ -0,0 +1,74 @@
+package store
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ObjectDeletion represents an object in the object store that is pending deletion.
+type ObjectDeletion struct {
+	gorm.Model
+
+	ObjectStorePath string
+
+	// Attempts is the number of failed deletion attempts.
+	Attempts  int
+	LastError string
+
+	NextAttemptAt time.Time `gorm:"index"`
+}
+
+// CreateObjectDeletionInTransaction enqueues the deletion of an object in a transaction.
+func CreateObjectDeletionInTransaction(tx *gorm.DB, objectStorePath string, now time.Time) (*ObjectDeletion, error) {
+	d := &ObjectDeletion{
+		ObjectStorePath: objectStorePath,
+		NextAttemptAt:   now,
+	}
+	if err := tx.Create(d).Error; err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// CreateObjectDeletion enqueues the deletion of an object.
+func (s *S) CreateObjectDeletion(objectStorePath string, now time.Time) (*ObjectDeletion, error) {
+	return CreateObjectDeletionInTransaction(s.db, objectStorePath, now)
+}
+
+// ListDueObjectDeletions lists object deletions whose next attempt time has passed.
+func (s *S) ListDueObjectDeletions(now time.Time, limit int) ([]*ObjectDeletion, error) {
+	var ds []*ObjectDeletion
+	if err := s.db.Where("next_attempt_at <= ?", now).Order("next_attempt_at").Limit(limit).Find(&ds).Error; err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// UpdateObjectDeletionFailure records a failed deletion attempt and schedules the next attempt.
+func (s *S) UpdateObjectDeletionFailure(d *ObjectDeletion, lastError string, nextAttemptAt time.Time) error {
+	res := s.db.Model(d).Updates(map[string]interface{}{
+		"attempts":        d.Attempts + 1,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	})
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteObjectDeletion deletes an object deletion.
+func (s *S) DeleteObjectDeletion(id uint) error {
+	res := s.db.Unscoped().Delete(&ObjectDeletion{}, id)
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
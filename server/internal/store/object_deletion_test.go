@@ -0,0 +1,44 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectDeletion(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	now := time.Now()
+	d0, err := st.CreateObjectDeletion("path0", now)
+	assert.NoError(t, err)
+	_, err = st.CreateObjectDeletion("path1", now.Add(time.Hour))
+	assert.NoError(t, err)
+
+	ds, err := st.ListDueObjectDeletions(now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 1)
+	assert.Equal(t, "path0", ds[0].ObjectStorePath)
+
+	err = st.UpdateObjectDeletionFailure(ds[0], "error", now.Add(time.Minute))
+	assert.NoError(t, err)
+
+	ds, err = st.ListDueObjectDeletions(now, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, ds)
+
+	ds, err = st.ListDueObjectDeletions(now.Add(2*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 2)
+	assert.Equal(t, 1, ds[0].Attempts)
+	assert.Equal(t, "error", ds[0].LastError)
+
+	err = st.DeleteObjectDeletion(d0.ID)
+	assert.NoError(t, err)
+	ds, err = st.ListDueObjectDeletions(now.Add(2*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 1)
+	assert.Equal(t, "path1", ds[0].ObjectStorePath)
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PresignedUpload represents an upload of a file with a presigned URL that has not been finalized.
+// It records the parameters given when the URL is issued so that only the objects uploaded with
+// the URLs issued by the server can be finalized.
+type PresignedUpload struct {
+	gorm.Model
+
+	// FileID is the ID of the file created when the upload is finalized.
+	FileID string `gorm:"uniqueIndex"`
+
+	TenantID       string `gorm:"index"`
+	OrganizationID string
+	ProjectID      string `gorm:"index"`
+
+	Filename string
+	Purpose  string
+
+	// EncryptionMode is the server-side encryption mode of the object. It is decided when the URL is issued.
+	EncryptionMode string
+
+	// ExpiresAt is the time after which the upload cannot be finalized.
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+// CreatePresignedUpload creates a presigned upload.
+func (s *S) CreatePresignedUpload(u *PresignedUpload) error {
+	return s.db.Create(u).Error
+}
+
+// GetPresignedUploadByFileIDAndProjectID returns a presigned upload by file ID and project ID.
+func (s *S) GetPresignedUploadByFileIDAndProjectID(fileID, projectID string) (*PresignedUpload, error) {
+	var u PresignedUpload
+	if err := s.db.Where("file_id = ? AND project_id = ?", fileID, projectID).Take(&u).Error; err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// ListPresignedUploadFileIDs returns the IDs of the files of presigned uploads among the given file IDs.
+func (s *S) ListPresignedUploadFileIDs(fileIDs []string) ([]string, error) {
+	if len(fileIDs) == 0 {
+		return nil, nil
+	}
+	var ids []string
+	if err := s.db.Model(&PresignedUpload{}).Where("file_id IN ?", fileIDs).Pluck("file_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// DeletePresignedUploadInTransaction deletes a presigned upload in a transaction. It returns gorm.ErrRecordNotFound
+// if the upload does not exist so that an upload is finalized only once.
+func DeletePresignedUploadInTransaction(tx *gorm.DB, fileID string) error {
+	res := tx.Unscoped().Where("file_id = ?", fileID).Delete(&PresignedUpload{})
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteExpiredPresignedUploads deletes presigned uploads that have expired and returns the number of deleted uploads.
+func (s *S) DeleteExpiredPresignedUploads(now time.Time) (int64, error) {
+	res := s.db.Unscoped().Where("expires_at <= ?", now).Delete(&PresignedUpload{})
+	if err := res.Error; err != nil {
+		return 0, err
+	}
+	return res.RowsAffected, nil
+}
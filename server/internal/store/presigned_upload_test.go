@@ -0,0 +1,48 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPresignedUpload(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	now := time.Now()
+	for i, expiresAt := range []time.Time{now.Add(time.Hour), now.Add(-time.Hour)} {
+		err := st.CreatePresignedUpload(&PresignedUpload{
+			FileID:    []string{"f0", "f1"}[i],
+			ProjectID: "pid0",
+			Purpose:   "fine-tune",
+			ExpiresAt: expiresAt,
+		})
+		assert.NoError(t, err)
+	}
+
+	_, err := st.GetPresignedUploadByFileIDAndProjectID("f0", "other")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	u, err := st.GetPresignedUploadByFileIDAndProjectID("f0", "pid0")
+	assert.NoError(t, err)
+	assert.Equal(t, "fine-tune", u.Purpose)
+
+	ids, err := st.ListPresignedUploadFileIDs([]string{"f0", "f1", "f2"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"f0", "f1"}, ids)
+
+	n, err := st.DeleteExpiredPresignedUploads(now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	_, err = st.GetPresignedUploadByFileIDAndProjectID("f1", "pid0")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	err = DeletePresignedUploadInTransaction(st.db, "f0")
+	assert.NoError(t, err)
+	err = DeletePresignedUploadInTransaction(st.db, "f0")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QuotaScope is the scope of a quota.
+type QuotaScope string
+
+const (
+	// QuotaScopeProject is the scope of a quota applied to a project.
+	QuotaScopeProject QuotaScope = "project"
+	// QuotaScopeTenant is the scope of a quota applied to a tenant.
+	QuotaScopeTenant QuotaScope = "tenant"
+)
+
+// QuotaOverride overrides the default quota of a project or a tenant.
+type QuotaOverride struct {
+	gorm.Model
+
+	Scope QuotaScope `gorm:"uniqueIndex:idx_quota_override_scope_id"`
+	// ScopeID is the ID of the project or the tenant.
+	ScopeID string `gorm:"uniqueIndex:idx_quota_override_scope_id"`
+
+	// MaxBytes is the maximum total size of files. The default is used if nil. There is no limit if 0.
+	MaxBytes *int64
+	// MaxFiles is the maximum number of files. The default is used if nil. There is no limit if 0.
+	MaxFiles *int64
+}
+
+// QuotaLock is a row per project or tenant that serializes the creations of files subject to a quota.
+type QuotaLock struct {
+	gorm.Model
+
+	Scope   QuotaScope `gorm:"uniqueIndex:idx_quota_lock_scope_id"`
+	ScopeID string     `gorm:"uniqueIndex:idx_quota_lock_scope_id"`
+
+	// LockCount is the number of times the row has been locked. It is incremented to lock the row.
+	LockCount int64
+}
+
+// LockQuotaInTransaction locks the quota of a project or a tenant until the transaction ends so that
+// the usage is read and a file is created without interleaving with other creations.
+func LockQuotaInTransaction(tx *gorm.DB, scope QuotaScope, scopeID string) error {
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "scope"}, {Name: "scope_id"}},
+		DoNothing: true,
+	}).Create(&QuotaLock{Scope: scope, ScopeID: scopeID}).Error; err != nil {
+		return err
+	}
+	// Update the row instead of using SELECT ... FOR UPDATE as it is not supported by SQLite.
+	return tx.Model(&QuotaLock{}).
+		Where("scope = ? AND scope_id = ?", scope, scopeID).
+		Update("lock_count", gorm.Expr("lock_count + 1")).Error
+}
+
+// FileUsage is the number and the total size of files.
+type FileUsage struct {
+	Files int64
+	Bytes int64
+}
+
+// GetQuotaOverrideInTransaction returns the quota override of a project or a tenant in a transaction.
+func GetQuotaOverrideInTransaction(tx *gorm.DB, scope QuotaScope, scopeID string) (*QuotaOverride, error) {
+	var o QuotaOverride
+	if err := tx.Where("scope = ? AND scope_id = ?", scope, scopeID).Take(&o).Error; err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// GetQuotaOverride returns the quota override of a project or a tenant.
+func (s *S) GetQuotaOverride(scope QuotaScope, scopeID string) (*QuotaOverride, error) {
+	return GetQuotaOverrideInTransaction(s.db, scope, scopeID)
+}
+
+// UpsertQuotaOverride creates or updates the quota override of a project or a tenant.
+func (s *S) UpsertQuotaOverride(o *QuotaOverride) error {
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "scope"}, {Name: "scope_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"max_bytes", "max_files", "updated_at"}),
+	}).Create(o).Error
+}
+
+// DeleteQuotaOverride deletes the quota override of a project or a tenant.
+func (s *S) DeleteQuotaOverride(scope QuotaScope, scopeID string) error {
+	res := s.db.Unscoped().Where("scope = ? AND scope_id = ?", scope, scopeID).Delete(&QuotaOverride{})
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetFileUsageInTransaction returns the number and the total size of files in a project or a tenant in a transaction.
+func GetFileUsageInTransaction(tx *gorm.DB, scope QuotaScope, scopeID string) (*FileUsage, error) {
+	var col string
+	switch scope {
+	case QuotaScopeProject:
+		col = "project_id"
+	case QuotaScopeTenant:
+		col = "tenant_id"
+	default:
+		return nil, fmt.Errorf("unknown quota scope: %q", scope)
+	}
+	var u FileUsage
+	if err := tx.Model(&File{}).
+		Select("COUNT(*) AS files, COALESCE(SUM(bytes), 0) AS bytes").
+		Where(col+" = ?", scopeID).
+		Scan(&u).Error; err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// GetFileUsage returns the number and the total size of files in a project or a tenant.
+func (s *S) GetFileUsage(scope QuotaScope, scopeID string) (*FileUsage, error) {
+	return GetFileUsageInTransaction(s.db, scope, scopeID)
+}
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestQuotaOverride(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	_, err := st.GetQuotaOverride(QuotaScopeProject, "pid0")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	maxBytes := int64(100)
+	err = st.UpsertQuotaOverride(&QuotaOverride{
+		Scope:    QuotaScopeProject,
+		ScopeID:  "pid0",
+		MaxBytes: &maxBytes,
+	})
+	assert.NoError(t, err)
+
+	o, err := st.GetQuotaOverride(QuotaScopeProject, "pid0")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), *o.MaxBytes)
+	assert.Nil(t, o.MaxFiles)
+
+	// The override is updated.
+	maxFiles := int64(2)
+	err = st.UpsertQuotaOverride(&QuotaOverride{
+		Scope:    QuotaScopeProject,
+		ScopeID:  "pid0",
+		MaxFiles: &maxFiles,
+	})
+	assert.NoError(t, err)
+
+	o, err = st.GetQuotaOverride(QuotaScopeProject, "pid0")
+	assert.NoError(t, err)
+	assert.Nil(t, o.MaxBytes)
+	assert.Equal(t, int64(2), *o.MaxFiles)
+
+	// Overrides of tenants are separate from overrides of projects.
+	_, err = st.GetQuotaOverride(QuotaScopeTenant, "pid0")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	err = st.DeleteQuotaOverride(QuotaScopeProject, "pid0")
+	assert.NoError(t, err)
+	err = st.DeleteQuotaOverride(QuotaScopeProject, "pid0")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+}
+
+func TestGetFileUsage(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	for i := 0; i < 3; i++ {
+		_, err := st.CreateFile(FileSpec{
+			FileID:    fmt.Sprintf("f%d", i),
+			TenantID:  "tid0",
+			ProjectID: fmt.Sprintf("pid%d", i%2),
+			Bytes:     int64(10 * (i + 1)),
+		})
+		assert.NoError(t, err)
+	}
+
+	u, err := st.GetFileUsage(QuotaScopeProject, "pid0")
+	assert.NoError(t, err)
+	assert.Equal(t, &FileUsage{Files: 2, Bytes: 40}, u)
+
+	u, err = st.GetFileUsage(QuotaScopeTenant, "tid0")
+	assert.NoError(t, err)
+	assert.Equal(t, &FileUsage{Files: 3, Bytes: 60}, u)
+
+	u, err = st.GetFileUsage(QuotaScopeTenant, "tid1")
+	assert.NoError(t, err)
+	assert.Equal(t, &FileUsage{}, u)
+}
+
+func TestLockQuotaInTransaction(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	for i := 0; i < 2; i++ {
+		err := st.Transaction(func(tx *gorm.DB) error {
+			if err := LockQuotaInTransaction(tx, QuotaScopeProject, "pid0"); err != nil {
+				return err
+			}
+			return LockQuotaInTransaction(tx, QuotaScopeTenant, "tid0")
+		})
+		assert.NoError(t, err)
+	}
+
+	var ls []QuotaLock
+	err := st.db.Order("scope").Find(&ls).Error
+	assert.NoError(t, err)
+	assert.Len(t, ls, 2)
+	for _, l := range ls {
+		assert.Equal(t, int64(2), l.LockCount)
+	}
+}
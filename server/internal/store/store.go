@@ -16,6 +16,11 @@ type S struct {
 	db *gorm.DB
 }
 
+// Transaction runs a given function in a transaction.
+func (s *S) Transaction(f func(*gorm.DB) error) error {
+	return s.db.Transaction(f)
+}
+
 // AutoMigrate sets up the auto-migration task of the database.
 func (s *S) AutoMigrate() error {
 	return autoMigrate(s.db)
@@ -23,6 +28,15 @@ func (s *S) AutoMigrate() error {
 
 func autoMigrate(db *gorm.DB) error {
 	return db.AutoMigrate(
+		&Blob{},
 		&File{},
+		&FileMetadata{},
+		&Lease{},
+		&ObjectDeletion{},
+		&PresignedUpload{},
+		&QuotaLock{},
+		&QuotaOverride{},
+		&Upload{},
+		&UploadPart{},
 	)
 }
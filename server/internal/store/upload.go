@@ -0,0 +1,145 @@
+package store
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UploadStatus is the status of an upload.
+type UploadStatus string
+
+const (
+	// UploadStatusPending is the status of an upload that accepts parts.
+	UploadStatusPending UploadStatus = "pending"
+	// UploadStatusCompleted is the status of an upload whose file has been created.
+	UploadStatusCompleted UploadStatus = "completed"
+	// UploadStatusCancelled is the status of a cancelled upload.
+	UploadStatusCancelled UploadStatus = "cancelled"
+	// UploadStatusExpired is the status of an upload that has not been completed before its expiration.
+	UploadStatusExpired UploadStatus = "expired"
+)
+
+// Upload represents a multi-part upload of a file.
+type Upload struct {
+	gorm.Model
+
+	UploadID string `gorm:"uniqueIndex"`
+
+	TenantID       string `gorm:"index"`
+	OrganizationID string
+	ProjectID      string `gorm:"index"`
+
+	Filename string
+	Purpose  string
+	MimeType string
+	Bytes    int64
+
+	Status    UploadStatus `gorm:"index"`
+	ExpiresAt time.Time
+
+	// FileID is the ID of the file created when the upload is completed. It is
+	// generated when the upload is created.
+	FileID          string
+	ObjectStorePath string
+	// MultipartUploadID is the ID of the multipart upload in the object store.
+	MultipartUploadID string
+	// EncryptionMode is the server-side encryption mode of the object. It is decided when the upload
+	// is created so that all parts are encrypted in the same way.
+	EncryptionMode string
+
+	// NextPartNumber is the part number assigned to the next part.
+	NextPartNumber int32
+}
+
+// UploadPart represents a part of an upload.
+type UploadPart struct {
+	gorm.Model
+
+	PartID   string `gorm:"uniqueIndex"`
+	UploadID string `gorm:"index"`
+
+	PartNumber int32
+	ETag       string
+	Bytes      int64
+}
+
+// CreateUpload creates an upload.
+func (s *S) CreateUpload(u *Upload) error {
+	return s.db.Create(u).Error
+}
+
+// GetUploadByUploadIDAndProjectID returns an upload by upload ID and project ID.
+func (s *S) GetUploadByUploadIDAndProjectID(uploadID, projectID string) (*Upload, error) {
+	var u Upload
+	if err := s.db.Where("upload_id = ? AND project_id = ?", uploadID, projectID).Take(&u).Error; err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// ListExpiredPendingUploads lists pending uploads that have expired.
+func (s *S) ListExpiredPendingUploads(now time.Time) ([]*Upload, error) {
+	var us []*Upload
+	if err := s.db.Where("status = ? AND expires_at <= ?", UploadStatusPending, now).Find(&us).Error; err != nil {
+		return nil, err
+	}
+	return us, nil
+}
+
+// UpdateUploadStatus updates the status of an upload if its current status matches.
+func (s *S) UpdateUploadStatus(uploadID string, from, to UploadStatus) error {
+	return UpdateUploadStatusInTransaction(s.db, uploadID, from, to)
+}
+
+// UpdateUploadStatusInTransaction updates the status of an upload if its current status matches in a transaction.
+func UpdateUploadStatusInTransaction(tx *gorm.DB, uploadID string, from, to UploadStatus) error {
+	res := tx.Model(&Upload{}).Where("upload_id = ? AND status = ?", uploadID, from).Update("status", to)
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// AllocateUploadPartNumber allocates a new part number for a pending upload.
+func (s *S) AllocateUploadPartNumber(uploadID string) (int32, error) {
+	var n int32
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Upload{}).
+			Where("upload_id = ? AND status = ?", uploadID, UploadStatusPending).
+			Update("next_part_number", gorm.Expr("next_part_number + 1"))
+		if err := res.Error; err != nil {
+			return err
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		var u Upload
+		if err := tx.Select("next_part_number").Where("upload_id = ?", uploadID).Take(&u).Error; err != nil {
+			return err
+		}
+		// Part numbers start from 1.
+		n = u.NextPartNumber
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// CreateUploadPart creates an upload part.
+func (s *S) CreateUploadPart(p *UploadPart) error {
+	return s.db.Create(p).Error
+}
+
+// ListUploadPartsByUploadID lists the parts of an upload ordered by their part numbers.
+func (s *S) ListUploadPartsByUploadID(uploadID string) ([]*UploadPart, error) {
+	var ps []*UploadPart
+	if err := s.db.Where("upload_id = ?", uploadID).Order("part_number").Find(&ps).Error; err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestUpload(t *testing.T) {
+	st, tearDown := NewTest(t)
+	defer tearDown()
+
+	const (
+		uploadID  = "u0"
+		projectID = "pid0"
+	)
+
+	now := time.Now()
+	err := st.CreateUpload(&Upload{
+		UploadID:  uploadID,
+		ProjectID: projectID,
+		Status:    UploadStatusPending,
+		ExpiresAt: now.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+
+	_, err = st.GetUploadByUploadIDAndProjectID(uploadID, "other")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	u, err := st.GetUploadByUploadIDAndProjectID(uploadID, projectID)
+	assert.NoError(t, err)
+	assert.Equal(t, UploadStatusPending, u.Status)
+
+	for i := 1; i <= 3; i++ {
+		n, err := st.AllocateUploadPartNumber(uploadID)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(i), n)
+
+		err = st.CreateUploadPart(&UploadPart{
+			PartID:     string(rune('a' + 3 - i)),
+			UploadID:   uploadID,
+			PartNumber: n,
+		})
+		assert.NoError(t, err)
+	}
+
+	ps, err := st.ListUploadPartsByUploadID(uploadID)
+	assert.NoError(t, err)
+	assert.Len(t, ps, 3)
+	for i, p := range ps {
+		assert.Equal(t, int32(i+1), p.PartNumber)
+	}
+
+	us, err := st.ListExpiredPendingUploads(now)
+	assert.NoError(t, err)
+	assert.Empty(t, us)
+	us, err = st.ListExpiredPendingUploads(now.Add(2 * time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, us, 1)
+
+	err = st.UpdateUploadStatus(uploadID, UploadStatusPending, UploadStatusCancelled)
+	assert.NoError(t, err)
+	err = st.UpdateUploadStatus(uploadID, UploadStatusPending, UploadStatusCompleted)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	// No part can be added to a cancelled upload.
+	_, err = st.AllocateUploadPartNumber(uploadID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	us, err = st.ListExpiredPendingUploads(now.Add(2 * time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, us)
+}
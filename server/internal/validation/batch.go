@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxBatchRequests is the maximum number of requests in a batch input file.
+const maxBatchRequests = 50000
+
+// batchEndpoints is the list of the endpoints supported by the Batch API.
+var batchEndpoints = []string{
+	"/v1/chat/completions",
+	"/v1/completions",
+	"/v1/embeddings",
+	"/v1/responses",
+}
+
+// BatchValidator validates lines of an input file of the Batch API
+// (https://platform.openai.com/docs/api-reference/batch/request-input).
+type BatchValidator struct {
+	// customIDs is the line numbers keyed by custom IDs.
+	customIDs map[string]int
+	// endpoint is the endpoint used by the requests in the file.
+	endpoint string
+}
+
+// NewBatchValidator returns a new BatchValidator.
+func NewBatchValidator() *BatchValidator {
+	return &BatchValidator{
+		customIDs: map[string]int{},
+	}
+}
+
+type batchRequest struct {
+	CustomID *string         `json:"custom_id"`
+	Method   string          `json:"method"`
+	URL      string          `json:"url"`
+	Body     json.RawMessage `json:"body"`
+}
+
+// ValidateLine implements LineValidator.
+func (v *BatchValidator) ValidateLine(n int, line []byte) error {
+	if n > maxBatchRequests {
+		// Report the error once, and neither decode nor record the rest of the requests so that
+		// the memory used by custom IDs is bounded by the limit.
+		if n == maxBatchRequests+1 {
+			return fmt.Errorf("the number of requests must not exceed %d", maxBatchRequests)
+		}
+		return nil
+	}
+
+	var req batchRequest
+	if err := decodeJSONObject(line, &req); err != nil {
+		return err
+	}
+
+	if req.CustomID == nil || *req.CustomID == "" {
+		return errors.New("custom_id is required")
+	}
+	if l, ok := v.customIDs[*req.CustomID]; ok {
+		return fmt.Errorf("duplicate custom_id %q. it is already used at line %d", *req.CustomID, l)
+	}
+	v.customIDs[*req.CustomID] = n
+
+	if req.Method != "POST" {
+		return fmt.Errorf("invalid method %q. must be \"POST\"", req.Method)
+	}
+	if !isBatchEndpoint(req.URL) {
+		return fmt.Errorf("unsupported url %q. must be one of %s", req.URL, strings.Join(batchEndpoints, ", "))
+	}
+	if v.endpoint == "" {
+		v.endpoint = req.URL
+	} else if req.URL != v.endpoint {
+		return fmt.Errorf("url %q is different from %q. all requests must use the same endpoint", req.URL, v.endpoint)
+	}
+
+	if b := bytes.TrimSpace(req.Body); len(b) == 0 || b[0] != '{' {
+		return errors.New("body must be a JSON object")
+	}
+	return nil
+}
+
+// Finish implements LineValidator.
+func (v *BatchValidator) Finish(lines int) error {
+	return nil
+}
+
+func isBatchEndpoint(url string) bool {
+	for _, e := range batchEndpoints {
+		if url == e {
+			return true
+		}
+	}
+	return false
+}
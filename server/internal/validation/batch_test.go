@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchValidator(t *testing.T) {
+	const (
+		line0 = `{"custom_id": "r0", "method": "POST", "url": "/v1/chat/completions", "body": {"model": "m", "messages": []}}`
+		line1 = `{"custom_id": "r1", "method": "POST", "url": "/v1/chat/completions", "body": {"model": "m", "messages": []}}`
+	)
+	tcs := []struct {
+		name     string
+		lines    []string
+		wantErrs []string
+	}{
+		{
+			name:  "valid",
+			lines: []string{line0, line1},
+		},
+		{
+			name:     "duplicate custom_id",
+			lines:    []string{line0, line1, line0},
+			wantErrs: []string{`line 3: duplicate custom_id "r0". it is already used at line 1`},
+		},
+		{
+			name:     "missing custom_id",
+			lines:    []string{`{"method": "POST", "url": "/v1/embeddings", "body": {}}`},
+			wantErrs: []string{"line 1: custom_id is required"},
+		},
+		{
+			name:     "invalid method",
+			lines:    []string{`{"custom_id": "r0", "method": "GET", "url": "/v1/embeddings", "body": {}}`},
+			wantErrs: []string{`line 1: invalid method "GET". must be "POST"`},
+		},
+		{
+			name:     "unsupported url",
+			lines:    []string{`{"custom_id": "r0", "method": "POST", "url": "/v1/images/generations", "body": {}}`},
+			wantErrs: []string{`line 1: unsupported url "/v1/images/generations". must be one of /v1/chat/completions, /v1/completions, /v1/embeddings, /v1/responses`},
+		},
+		{
+			name: "different endpoints",
+			lines: []string{
+				line0,
+				`{"custom_id": "r1", "method": "POST", "url": "/v1/embeddings", "body": {"model": "m", "input": "Hi"}}`,
+			},
+			wantErrs: []string{`line 2: url "/v1/embeddings" is different from "/v1/chat/completions". all requests must use the same endpoint`},
+		},
+		{
+			name:     "missing body",
+			lines:    []string{`{"custom_id": "r0", "method": "POST", "url": "/v1/embeddings"}`},
+			wantErrs: []string{"line 1: body must be a JSON object"},
+		},
+		{
+			name:     "invalid body",
+			lines:    []string{`{"custom_id": "r0", "method": "POST", "url": "/v1/embeddings", "body": "Hi"}`},
+			wantErrs: []string{"line 1: body must be a JSON object"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewJSONLValidator(NewBatchValidator(), 10)
+			_, err := v.Write([]byte(strings.Join(tc.lines, "\n")))
+			assert.NoError(t, err)
+			v.Flush()
+			var errs []string
+			for _, e := range v.Errors() {
+				errs = append(errs, e.Error())
+			}
+			assert.Equal(t, tc.wantErrs, errs)
+		})
+	}
+}
+
+func TestBatchValidator_TooManyRequests(t *testing.T) {
+	bv := NewBatchValidator()
+	v := NewJSONLValidator(bv, 10)
+	for i := 0; i < maxBatchRequests+10; i++ {
+		_, err := fmt.Fprintf(v, `{"custom_id": "r%d", "method": "POST", "url": "/v1/embeddings", "body": {}}`+"\n", i)
+		assert.NoError(t, err)
+	}
+	v.Flush()
+	assert.Len(t, v.Errors(), 1)
+	assert.Equal(t, maxBatchRequests+1, v.Errors()[0].Line)
+	assert.Len(t, bv.customIDs, maxBatchRequests)
+}
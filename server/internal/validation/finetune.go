@@ -0,0 +1,142 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// FineTuneValidator validates lines of a fine-tuning dataset in the chat format
+// (https://platform.openai.com/docs/api-reference/fine-tuning/chat-input).
+type FineTuneValidator struct{}
+
+// NewFineTuneValidator returns a new FineTuneValidator.
+func NewFineTuneValidator() *FineTuneValidator {
+	return &FineTuneValidator{}
+}
+
+type fineTuneExample struct {
+	Messages          []json.RawMessage `json:"messages"`
+	Tools             []json.RawMessage `json:"tools"`
+	ParallelToolCalls *bool             `json:"parallel_tool_calls"`
+}
+
+type fineTuneMessage struct {
+	Role      string            `json:"role"`
+	Content   json.RawMessage   `json:"content"`
+	Name      *string           `json:"name"`
+	Weight    *int              `json:"weight"`
+	ToolCalls []json.RawMessage `json:"tool_calls"`
+}
+
+type fineTuneTool struct {
+	Type     string `json:"type"`
+	Function *struct {
+		Name string `json:"name"`
+	} `json:"function"`
+}
+
+// ValidateLine implements LineValidator.
+func (v *FineTuneValidator) ValidateLine(n int, line []byte) error {
+	var ex fineTuneExample
+	if err := decodeJSONObject(line, &ex); err != nil {
+		return err
+	}
+	if len(ex.Messages) == 0 {
+		return errors.New("messages must be a non-empty array")
+	}
+
+	var hasAssistant bool
+	for i, raw := range ex.Messages {
+		var m fineTuneMessage
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return fmt.Errorf("messages[%d]: invalid message: %s", i, err)
+		}
+		if err := validateMessage(&m); err != nil {
+			return fmt.Errorf("messages[%d]: %s", i, err)
+		}
+		if m.Role == "assistant" {
+			hasAssistant = true
+		}
+	}
+	if !hasAssistant {
+		return errors.New("messages must contain at least one assistant message")
+	}
+
+	for i, raw := range ex.Tools {
+		var t fineTuneTool
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return fmt.Errorf("tools[%d]: invalid tool: %s", i, err)
+		}
+		if t.Type != "function" {
+			return fmt.Errorf("tools[%d]: type must be \"function\"", i)
+		}
+		if t.Function == nil || t.Function.Name == "" {
+			return fmt.Errorf("tools[%d]: function.name is required", i)
+		}
+	}
+	return nil
+}
+
+// Finish implements LineValidator.
+func (v *FineTuneValidator) Finish(lines int) error {
+	return nil
+}
+
+func validateMessage(m *fineTuneMessage) error {
+	switch m.Role {
+	case "system", "developer", "user", "assistant", "tool":
+	case "":
+		return errors.New("role is required")
+	default:
+		return fmt.Errorf("invalid role %q", m.Role)
+	}
+
+	if m.Weight != nil {
+		if m.Role != "assistant" {
+			return errors.New("weight can be set only for assistant messages")
+		}
+		if *m.Weight != 0 && *m.Weight != 1 {
+			return errors.New("weight must be either 0 or 1")
+		}
+	}
+
+	// Assistant messages with tool calls can have no content.
+	if m.Role == "assistant" && len(m.ToolCalls) > 0 && isNull(m.Content) {
+		return nil
+	}
+	return validateContent(m.Content)
+}
+
+// validateContent validates that the content is either a non-empty string or an array of content parts.
+func validateContent(c json.RawMessage) error {
+	if isNull(c) {
+		return errors.New("content is required")
+	}
+	var s string
+	if err := json.Unmarshal(c, &s); err == nil {
+		if s == "" {
+			return errors.New("content must not be empty")
+		}
+		return nil
+	}
+	var parts []struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(c, &parts); err != nil {
+		return errors.New("content must be a string or an array of content parts")
+	}
+	if len(parts) == 0 {
+		return errors.New("content must not be empty")
+	}
+	for i, p := range parts {
+		if p.Type == "" {
+			return fmt.Errorf("content[%d]: type is required", i)
+		}
+	}
+	return nil
+}
+
+func isNull(r json.RawMessage) bool {
+	return len(r) == 0 || string(r) == "null"
+}
@@ -0,0 +1,93 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFineTuneValidator(t *testing.T) {
+	tcs := []struct {
+		name    string
+		line    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			line: `{"messages": [{"role": "system", "content": "You are a helpful assistant."}, {"role": "user", "content": "Hi"}, {"role": "assistant", "content": "Hello", "weight": 1}]}`,
+		},
+		{
+			name: "content parts",
+			line: `{"messages": [{"role": "user", "content": [{"type": "text", "text": "Hi"}]}, {"role": "assistant", "content": "Hello"}]}`,
+		},
+		{
+			name: "tool calls",
+			line: `{"messages": [{"role": "user", "content": "Weather?"}, {"role": "assistant", "tool_calls": [{"id": "c0", "type": "function", "function": {"name": "get_weather", "arguments": "{}"}}]}, {"role": "tool", "content": "Sunny"}, {"role": "assistant", "content": "It is sunny."}], "tools": [{"type": "function", "function": {"name": "get_weather"}}]}`,
+		},
+		{
+			name:    "invalid JSON",
+			line:    `{"messages": `,
+			wantErr: "invalid JSON",
+		},
+		{
+			name:    "not an object",
+			line:    `[{"role": "user", "content": "Hi"}]`,
+			wantErr: "line must be a JSON object",
+		},
+		{
+			name:    "no messages",
+			line:    `{"prompt": "Hi", "completion": "Hello"}`,
+			wantErr: "messages must be a non-empty array",
+		},
+		{
+			name:    "no assistant message",
+			line:    `{"messages": [{"role": "user", "content": "Hi"}]}`,
+			wantErr: "messages must contain at least one assistant message",
+		},
+		{
+			name:    "missing role",
+			line:    `{"messages": [{"content": "Hi"}, {"role": "assistant", "content": "Hello"}]}`,
+			wantErr: "messages[0]: role is required",
+		},
+		{
+			name:    "invalid role",
+			line:    `{"messages": [{"role": "bot", "content": "Hi"}]}`,
+			wantErr: `messages[0]: invalid role "bot"`,
+		},
+		{
+			name:    "missing content",
+			line:    `{"messages": [{"role": "user"}, {"role": "assistant", "content": "Hello"}]}`,
+			wantErr: "messages[0]: content is required",
+		},
+		{
+			name:    "invalid content",
+			line:    `{"messages": [{"role": "user", "content": 1}, {"role": "assistant", "content": "Hello"}]}`,
+			wantErr: "messages[0]: content must be a string or an array of content parts",
+		},
+		{
+			name:    "invalid weight",
+			line:    `{"messages": [{"role": "user", "content": "Hi"}, {"role": "assistant", "content": "Hello", "weight": 2}]}`,
+			wantErr: "messages[1]: weight must be either 0 or 1",
+		},
+		{
+			name:    "weight on user message",
+			line:    `{"messages": [{"role": "user", "content": "Hi", "weight": 1}, {"role": "assistant", "content": "Hello"}]}`,
+			wantErr: "messages[0]: weight can be set only for assistant messages",
+		},
+		{
+			name:    "invalid tool",
+			line:    `{"messages": [{"role": "user", "content": "Hi"}, {"role": "assistant", "content": "Hello"}], "tools": [{"type": "function", "function": {}}]}`,
+			wantErr: "tools[0]: function.name is required",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewFineTuneValidator().ValidateLine(1, []byte(tc.line))
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// maxLineBytes is the maximum size of a line.
+	maxLineBytes = 16 << 20
+)
+
+// ErrTooManyErrors is returned by Write when the number of errors reaches the maximum.
+var ErrTooManyErrors = errors.New("too many validation errors")
+
+// LineError is an error found in a line.
+type LineError struct {
+	// Line is the 1-based line number. It is 0 for an error of the entire file.
+	Line    int
+	Message string
+}
+
+// Error implements error.
+func (e *LineError) Error() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// LineValidator validates the lines of a JSONL file.
+type LineValidator interface {
+	// ValidateLine validates a line. n is the 1-based line number. The line does not contain the trailing newline.
+	ValidateLine(n int, line []byte) error
+	// Finish validates the file after all the lines have been validated.
+	Finish(lines int) error
+}
+
+// NewJSONLValidator returns a new JSONLValidator.
+func NewJSONLValidator(v LineValidator, maxErrors int) *JSONLValidator {
+	return &JSONLValidator{
+		v:         v,
+		maxErrors: maxErrors,
+	}
+}
+
+// JSONLValidator is an io.Writer that splits the written content into lines and validates them.
+//
+// Write returns ErrTooManyErrors once the number of errors reaches the maximum so that the caller
+// can stop reading the rest of the content.
+type JSONLValidator struct {
+	v         LineValidator
+	maxErrors int
+
+	buf      []byte
+	skipping bool
+	lines    int
+	errs     []*LineError
+	errCount int
+	flushed  bool
+}
+
+// Write implements io.Writer.
+func (j *JSONLValidator) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 && !j.full() {
+		i := bytes.IndexByte(p, '\n')
+		chunk := p
+		if i >= 0 {
+			chunk = p[:i]
+		}
+		if !j.skipping {
+			if len(j.buf)+len(chunk) > maxLineBytes {
+				// Skip the rest of the line.
+				j.addError(j.lines+1, fmt.Sprintf("line exceeds %d bytes", maxLineBytes))
+				j.skipping = true
+				j.buf = j.buf[:0]
+			} else {
+				j.buf = append(j.buf, chunk...)
+			}
+		}
+		if i < 0 {
+			break
+		}
+		if j.skipping {
+			j.lines++
+			j.skipping = false
+		} else {
+			j.validateLine(j.buf, false)
+		}
+		j.buf = j.buf[:0]
+		p = p[i+1:]
+	}
+	if j.full() {
+		return n, ErrTooManyErrors
+	}
+	return n, nil
+}
+
+// Flush validates the last line and the entire file. It must be called after all the content is written.
+func (j *JSONLValidator) Flush() {
+	if j.flushed {
+		return
+	}
+	j.flushed = true
+	if j.full() {
+		return
+	}
+	if j.skipping {
+		j.lines++
+	} else if len(j.buf) > 0 {
+		j.validateLine(j.buf, true)
+	}
+	j.buf = nil
+	if j.full() {
+		return
+	}
+	if j.lines == 0 {
+		j.addError(0, "file is empty")
+		return
+	}
+	if err := j.v.Finish(j.lines); err != nil {
+		j.addLineError(0, err)
+	}
+}
+
+// Lines returns the number of validated lines.
+func (j *JSONLValidator) Lines() int {
+	return j.lines
+}
+
+// Errors returns the errors found in the content. The number of the returned errors is capped
+// by the maximum.
+func (j *JSONLValidator) Errors() []*LineError {
+	return j.errs
+}
+
+// Valid returns true if no error has been found.
+func (j *JSONLValidator) Valid() bool {
+	return j.errCount == 0
+}
+
+// Summary returns a message that summarizes the errors.
+func (j *JSONLValidator) Summary() string {
+	var msgs []string
+	for _, e := range j.errs {
+		msgs = append(msgs, e.Error())
+	}
+	s := strings.Join(msgs, "; ")
+	if j.full() {
+		s += fmt.Sprintf("; stopped after %d errors", j.maxErrors)
+	}
+	return s
+}
+
+func (j *JSONLValidator) validateLine(line []byte, last bool) {
+	j.lines++
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	if len(bytes.TrimSpace(line)) == 0 {
+		if last {
+			// Allow trailing whitespace at the end of the file.
+			j.lines--
+			return
+		}
+		j.addError(j.lines, "line is empty")
+		return
+	}
+	if err := j.v.ValidateLine(j.lines, line); err != nil {
+		j.addLineError(j.lines, err)
+	}
+}
+
+func (j *JSONLValidator) addLineError(line int, err error) {
+	var le *LineError
+	if errors.As(err, &le) {
+		j.addError(le.Line, le.Message)
+		return
+	}
+	j.addError(line, err.Error())
+}
+
+func (j *JSONLValidator) addError(line int, msg string) {
+	j.errCount++
+	if len(j.errs) < j.maxErrors {
+		j.errs = append(j.errs, &LineError{Line: line, Message: msg})
+	}
+}
+
+func (j *JSONLValidator) full() bool {
+	return j.errCount >= j.maxErrors
+}
+
+// JSONObjectValidator validates that each line is a JSON object.
+type JSONObjectValidator struct{}
+
+// NewJSONObjectValidator returns a new JSONObjectValidator.
+func NewJSONObjectValidator() *JSONObjectValidator {
+	return &JSONObjectValidator{}
+}
+
+// ValidateLine implements LineValidator.
+func (v *JSONObjectValidator) ValidateLine(n int, line []byte) error {
+	var m map[string]json.RawMessage
+	return decodeJSONObject(line, &m)
+}
+
+// Finish implements LineValidator.
+func (v *JSONObjectValidator) Finish(lines int) error {
+	return nil
+}
+
+// decodeJSONObject decodes a line that must be a JSON object.
+func decodeJSONObject(line []byte, v any) error {
+	if !json.Valid(line) {
+		return errors.New("invalid JSON")
+	}
+	if b := bytes.TrimSpace(line); b[0] != '{' {
+		return errors.New("line must be a JSON object")
+	}
+	if err := json.Unmarshal(line, v); err != nil {
+		return fmt.Errorf("invalid JSON object: %s", err)
+	}
+	return nil
+}
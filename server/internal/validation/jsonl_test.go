@@ -0,0 +1,86 @@
+package validation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONLValidator(t *testing.T) {
+	tcs := []struct {
+		name      string
+		content   string
+		wantLines int
+		wantErrs  []string
+	}{
+		{
+			name:      "valid",
+			content:   "{\"a\": 1}\n{\"b\": 2}\n",
+			wantLines: 2,
+		},
+		{
+			name:      "no trailing newline",
+			content:   "{\"a\": 1}\r\n{\"b\": 2}",
+			wantLines: 2,
+		},
+		{
+			name:      "invalid lines",
+			content:   "{\"a\": 1}\n[1]\n\n{\"b\": 2}\n",
+			wantLines: 4,
+			wantErrs: []string{
+				"line 2: line must be a JSON object",
+				"line 3: line is empty",
+			},
+		},
+		{
+			name:     "empty",
+			content:  "",
+			wantErrs: []string{"file is empty"},
+		},
+		{
+			name:      "too many errors",
+			content:   strings.Repeat("x\n", 10),
+			wantLines: 3,
+			wantErrs: []string{
+				"line 1: invalid JSON",
+				"line 2: invalid JSON",
+				"line 3: invalid JSON",
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewJSONLValidator(NewJSONObjectValidator(), 3)
+			// Write the content in small chunks to split lines across writes.
+			_, err := io.CopyBuffer(struct{ io.Writer }{v}, struct{ io.Reader }{strings.NewReader(tc.content)}, make([]byte, 3))
+			if len(tc.wantErrs) == 3 {
+				assert.ErrorIs(t, err, ErrTooManyErrors)
+			} else {
+				assert.NoError(t, err)
+			}
+			v.Flush()
+
+			assert.Equal(t, tc.wantLines, v.Lines())
+			assert.Equal(t, len(tc.wantErrs) == 0, v.Valid())
+			var errs []string
+			for _, e := range v.Errors() {
+				errs = append(errs, e.Error())
+			}
+			assert.Equal(t, tc.wantErrs, errs)
+		})
+	}
+}
+
+func TestJSONLValidator_LongLine(t *testing.T) {
+	v := NewJSONLValidator(NewJSONObjectValidator(), 3)
+	content := fmt.Sprintf("{\"a\": %q}\n{\"b\": 2}\n", strings.Repeat("x", maxLineBytes))
+	_, err := io.Copy(v, strings.NewReader(content))
+	assert.NoError(t, err)
+	v.Flush()
+	assert.Equal(t, 2, v.Lines())
+	assert.Len(t, v.Errors(), 1)
+	assert.Equal(t, 1, v.Errors()[0].Line)
+}
@@ -5,6 +5,7 @@
 */
 
 import * as fm from "../../fetch.pb"
+import * as GoogleProtobufField_mask from "../../google/protobuf/field_mask.pb"
 export type File = {
   id?: string
   bytes?: string
@@ -13,6 +14,19 @@ export type File = {
   object?: string
   purpose?: string
   object_store_path?: string
+  object_verification_status?: string
+  status?: string
+  status_details?: string
+  expires_at?: string
+  sha256?: string
+  md5?: string
+  metadata?: {[key: string]: string}
+  version?: string
+}
+
+export type ExpiresAfter = {
+  anchor?: string
+  seconds?: string
 }
 
 export type ListFilesRequest = {
@@ -20,6 +34,17 @@ export type ListFilesRequest = {
   after?: string
   limit?: number
   order?: string
+  status?: string
+  filename_prefix?: string
+  filename_contains?: string
+  created_at_start?: string
+  created_at_end?: string
+  min_bytes?: string
+  max_bytes?: string
+  object_store_path_prefix?: string
+  sort_by?: string
+  metadata_selector?: string
+  cursor?: string
 }
 
 export type ListFilesResponse = {
@@ -27,12 +52,18 @@ export type ListFilesResponse = {
   data?: File[]
   has_more?: boolean
   total_items?: number
+  next_cursor?: string
 }
 
 export type GetFileRequest = {
   id?: string
 }
 
+export type UpdateFileRequest = {
+  file?: File
+  update_mask?: GoogleProtobufField_mask.FieldMask
+}
+
 export type DeleteFileRequest = {
   id?: string
 }
@@ -46,6 +77,101 @@ export type DeleteFileResponse = {
 export type CreateFileFromObjectPathRequest = {
   object_path?: string
   purpose?: string
+  expires_after?: ExpiresAfter
+  metadata?: {[key: string]: string}
+}
+
+export type Upload = {
+  id?: string
+  object?: string
+  bytes?: string
+  created_at?: string
+  filename?: string
+  purpose?: string
+  status?: string
+  expires_at?: string
+  file?: File
+}
+
+export type CreateUploadRequest = {
+  filename?: string
+  purpose?: string
+  bytes?: string
+  mime_type?: string
+}
+
+export type CompleteUploadRequest = {
+  id?: string
+  part_ids?: string[]
+  md5?: string
+}
+
+export type CancelUploadRequest = {
+  id?: string
+}
+
+export type CreateFileUploadURLRequest = {
+  filename?: string
+  purpose?: string
+}
+
+export type CreateFileUploadURLResponse = {
+  file_id?: string
+  url?: string
+  expires_at?: string
+}
+
+export type FinalizeFileUploadRequest = {
+  file_id?: string
+  filename?: string
+  purpose?: string
+}
+
+export type GetFileDownloadURLRequest = {
+  id?: string
+}
+
+export type GetFileDownloadURLResponse = {
+  url?: string
+  expires_at?: string
+}
+
+export type GetFileCapabilitiesRequest = {
+}
+
+export type FileCapabilities = {
+  file_upload_enabled?: boolean
+}
+
+export type GetUsageRequest = {
+}
+
+export type QuotaUsage = {
+  bytes?: string
+  files?: string
+  max_bytes?: string
+  max_files?: string
+}
+
+export type Usage = {
+  project?: QuotaUsage
+  tenant?: QuotaUsage
+}
+
+export type GetFileStatsRequest = {
+}
+
+export type FileCount = {
+  key?: string
+  files?: string
+  bytes?: string
+}
+
+export type FileStats = {
+  files?: string
+  bytes?: string
+  by_purpose?: FileCount[]
+  by_status?: FileCount[]
 }
 
 export type GetFilePathRequest = {
@@ -55,6 +181,28 @@ export type GetFilePathRequest = {
 export type GetFilePathResponse = {
   path?: string
   filename?: string
+  sha256?: string
+  md5?: string
+  encryption_mode?: string
+}
+
+export type ListFilesPendingObjectVerificationRequest = {
+  limit?: number
+}
+
+export type ListFilesPendingObjectVerificationResponse = {
+  files?: File[]
+}
+
+export type UpdateFileObjectVerificationRequest = {
+  id?: string
+  exists?: boolean
+  bytes?: string
+  etag?: string
+  last_modified_at?: string
+}
+
+export type UpdateFileObjectVerificationResponse = {
 }
 
 export class FilesService {
@@ -64,17 +212,53 @@ export class FilesService {
   static GetFile(req: GetFileRequest, initReq?: fm.InitReq): Promise<File> {
     return fm.fetchReq<GetFileRequest, File>(`/v1/files/${req["id"]}?${fm.renderURLSearchParams(req, ["id"])}`, {...initReq, method: "GET"})
   }
+  static UpdateFile(req: UpdateFileRequest, initReq?: fm.InitReq): Promise<File> {
+    return fm.fetchReq<UpdateFileRequest, File>(`/v1/files/${req["file.id"]}`, {...initReq, method: "PATCH", body: JSON.stringify(req["file"])})
+  }
   static DeleteFile(req: DeleteFileRequest, initReq?: fm.InitReq): Promise<DeleteFileResponse> {
     return fm.fetchReq<DeleteFileRequest, DeleteFileResponse>(`/v1/files/${req["id"]}`, {...initReq, method: "DELETE"})
   }
   static CreateFileFromObjectPath(req: CreateFileFromObjectPathRequest, initReq?: fm.InitReq): Promise<File> {
     return fm.fetchReq<CreateFileFromObjectPathRequest, File>(`/v1/files:createFromObjectPath`, {...initReq, method: "POST", body: JSON.stringify(req)})
   }
+  static CreateFileUploadURL(req: CreateFileUploadURLRequest, initReq?: fm.InitReq): Promise<CreateFileUploadURLResponse> {
+    return fm.fetchReq<CreateFileUploadURLRequest, CreateFileUploadURLResponse>(`/v1/files:createUploadURL`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static FinalizeFileUpload(req: FinalizeFileUploadRequest, initReq?: fm.InitReq): Promise<File> {
+    return fm.fetchReq<FinalizeFileUploadRequest, File>(`/v1/files:finalizeUpload`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static GetFileDownloadURL(req: GetFileDownloadURLRequest, initReq?: fm.InitReq): Promise<GetFileDownloadURLResponse> {
+    return fm.fetchReq<GetFileDownloadURLRequest, GetFileDownloadURLResponse>(`/v1/files/${req["id"]}/download_url?${fm.renderURLSearchParams(req, ["id"])}`, {...initReq, method: "GET"})
+  }
+  static GetFileCapabilities(req: GetFileCapabilitiesRequest, initReq?: fm.InitReq): Promise<FileCapabilities> {
+    return fm.fetchReq<GetFileCapabilitiesRequest, FileCapabilities>(`/v1/files:capabilities?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static GetUsage(req: GetUsageRequest, initReq?: fm.InitReq): Promise<Usage> {
+    return fm.fetchReq<GetUsageRequest, Usage>(`/v1/files:usage?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static GetFileStats(req: GetFileStatsRequest, initReq?: fm.InitReq): Promise<FileStats> {
+    return fm.fetchReq<GetFileStatsRequest, FileStats>(`/v1/files:stats?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static CreateUpload(req: CreateUploadRequest, initReq?: fm.InitReq): Promise<Upload> {
+    return fm.fetchReq<CreateUploadRequest, Upload>(`/v1/uploads`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static CompleteUpload(req: CompleteUploadRequest, initReq?: fm.InitReq): Promise<Upload> {
+    return fm.fetchReq<CompleteUploadRequest, Upload>(`/v1/uploads/${req["id"]}/complete`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static CancelUpload(req: CancelUploadRequest, initReq?: fm.InitReq): Promise<Upload> {
+    return fm.fetchReq<CancelUploadRequest, Upload>(`/v1/uploads/${req["id"]}/cancel`, {...initReq, method: "POST"})
+  }
 }
 export class FilesWorkerService {
   static GetFilePath(req: GetFilePathRequest, initReq?: fm.InitReq): Promise<GetFilePathResponse> {
     return fm.fetchReq<GetFilePathRequest, GetFilePathResponse>(`/llmariner.files.server.v1.FilesWorkerService/GetFilePath`, {...initReq, method: "POST", body: JSON.stringify(req)})
   }
+  static ListFilesPendingObjectVerification(req: ListFilesPendingObjectVerificationRequest, initReq?: fm.InitReq): Promise<ListFilesPendingObjectVerificationResponse> {
+    return fm.fetchReq<ListFilesPendingObjectVerificationRequest, ListFilesPendingObjectVerificationResponse>(`/llmariner.files.server.v1.FilesWorkerService/ListFilesPendingObjectVerification`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static UpdateFileObjectVerification(req: UpdateFileObjectVerificationRequest, initReq?: fm.InitReq): Promise<UpdateFileObjectVerificationResponse> {
+    return fm.fetchReq<UpdateFileObjectVerificationRequest, UpdateFileObjectVerificationResponse>(`/llmariner.files.server.v1.FilesWorkerService/UpdateFileObjectVerification`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
 }
 export class FilesInternalService {
   static GetFilePath(req: GetFilePathRequest, initReq?: fm.InitReq): Promise<GetFilePathResponse> {
@@ -0,0 +1,8 @@
+/* eslint-disable */
+// @ts-nocheck
+/*
+* This file is a generated Typescript file for GRPC Gateway, DO NOT MODIFY
+*/
+export type FieldMask = {
+  paths?: string[]
+}
\ No newline at end of file